@@ -46,7 +46,7 @@ func TestPostAlbum(t *testing.T) {
 				Path:        "/album",
 				ExpCode:     400,
 				ExpFields:   []string{"Artist[0]"},
-				ExpMessages: []string{"Artist [ 0 ] must contain at least 2 characters"},
+				ExpMessages: []string{"Artist entry 1 must contain at least 2 characters"},
 				Body: models.AlbumExample{
 					Artist: []string{"f"},
 					Name:   "dude ranch",
@@ -57,7 +57,7 @@ func TestPostAlbum(t *testing.T) {
 				Path:        "/album",
 				ExpCode:     400,
 				ExpFields:   []string{"Artist[0]"},
-				ExpMessages: []string{"Artist [ 0 ] must contain no more than 50 characters"},
+				ExpMessages: []string{"Artist entry 1 must contain no more than 50 characters"},
 				Body: models.AlbumExample{
 					Artist: []string{"asdfasdfasdfasdfasdfasdfasdfasdfasdfasdfasdfasdfasd"},
 					Name:   "dude ranch",
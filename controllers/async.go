@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// asyncTag holds the checks that need the request's context, ex: a
+// database lookup - `async:"available=usernames"`. They run after the
+// binding tags, and only for fields that passed them.
+const asyncTag = "async"
+
+// The tags used when an async check couldn't give an answer.
+const (
+	tagAsyncTimeout     = "async_timeout"
+	tagAsyncUnavailable = "async_unavailable"
+)
+
+// AsyncTimeout is how long all the async checks for a request get to
+// finish, on top of any deadline already on the request's context.
+var AsyncTimeout = 2 * time.Second
+
+// AsyncFunc checks a single field's value. It should give up when ctx is
+// done - a false result means the field is invalid, an error means the
+// check couldn't be made.
+type AsyncFunc func(ctx context.Context, value interface{}, param string) (bool, error)
+
+var (
+	asyncFuncsMu sync.RWMutex
+	asyncFuncs   = map[string]AsyncFunc{}
+)
+
+// RegisterAsyncValidation will add a tag that can be used in the async
+// struct tag, along with the message template used when it fails. Call it
+// before GetRouter.
+//
+// ex: RegisterAsyncValidation("available", isAvailable, "{field} is already taken")
+func RegisterAsyncValidation(tag string, fn AsyncFunc, template string) {
+	asyncFuncsMu.Lock()
+	asyncFuncs[tag] = fn
+	asyncFuncsMu.Unlock()
+	Messages.Register(tag, template)
+}
+
+func asyncFunc(tag string) (AsyncFunc, bool) {
+	asyncFuncsMu.RLock()
+	defer asyncFuncsMu.RUnlock()
+	fn, ok := asyncFuncs[tag]
+	return fn, ok
+}
+
+// asyncErrors are the field errors from a model's async checks.
+type asyncErrors struct {
+	Type   reflect.Type
+	Errors []*fieldError
+}
+
+func (ae *asyncErrors) Error() string {
+	msgs := make([]string, len(ae.Errors))
+	for i, e := range ae.Errors {
+		msgs[i] = fmt.Sprintf("%s failed on the '%s' async check", e.Namespace, e.Tag)
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// asyncCheck is every async rule for a single field - the rules run in
+// order, and the first one to fail is the field's error.
+type asyncCheck struct {
+	Namespace string
+	Value     reflect.Value
+	Rules     []string
+}
+
+// runAsyncChecks will run the async checks for obj, one goroutine per
+// field, skipping the fields in failed. It returns nil if everything passed.
+func runAsyncChecks(ctx context.Context, obj interface{}, failed map[string]bool) *asyncErrors {
+	val := reflect.Indirect(reflect.ValueOf(obj))
+	checks := collectAsyncChecks(val, "", failed, nil)
+	if len(checks) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, AsyncTimeout)
+	defer cancel()
+
+	results := make([]*fieldError, len(checks))
+	finished := make([]bool, len(checks))
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check asyncCheck) {
+			defer wg.Done()
+			fe := check.run(ctx)
+			mu.Lock()
+			results[i], finished[i] = fe, true
+			mu.Unlock()
+		}(i, check)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// don't wait on checks that ignore their context
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	errs := &asyncErrors{Type: val.Type()}
+	for i, fe := range results {
+		if !finished[i] {
+			fe = checks[i].fieldError(tagAsyncTimeout, "")
+		}
+		if fe != nil {
+			errs.Errors = append(errs.Errors, fe)
+		}
+	}
+	if len(errs.Errors) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// run will check each rule in turn, returning the first that fails.
+func (ac asyncCheck) run(ctx context.Context) *fieldError {
+	for _, rule := range ac.Rules {
+		parts := strings.SplitN(rule, "=", 2)
+		tag, param := parts[0], ""
+		if len(parts) == 2 {
+			param = parts[1]
+		}
+		fn, ok := asyncFunc(tag)
+		if !ok {
+			log.Printf("no async validation registered for tag: %s", tag)
+			return ac.fieldError(tagAsyncUnavailable, "")
+		}
+
+		ok, err := fn(ctx, ac.Value.Interface(), param)
+		switch {
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
+			return ac.fieldError(tagAsyncTimeout, "")
+		case err != nil:
+			return ac.fieldError(tagAsyncUnavailable, "")
+		case !ok:
+			return ac.fieldError(tag, param)
+		}
+	}
+	return nil
+}
+
+func (ac asyncCheck) fieldError(tag string, param string) *fieldError {
+	return &fieldError{
+		Namespace: ac.Namespace,
+		Tag:       tag,
+		Param:     param,
+		Kind:      ac.Value.Kind(),
+		Type:      ac.Value.Type(),
+		Value:     ac.Value.Interface(),
+	}
+}
+
+// collectAsyncChecks will find every field with an async tag, including
+// those in nested structs and slices of structs.
+func collectAsyncChecks(val reflect.Value, ns string, failed map[string]bool, checks []asyncCheck) []asyncCheck {
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return checks
+		}
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			checks = collectAsyncChecks(val.Index(i), fmt.Sprintf("%s[%d]", ns, i), failed, checks)
+		}
+		return checks
+	case reflect.Struct:
+	default:
+		return checks
+	}
+	if val.Type() == reflect.TypeOf(time.Time{}) {
+		return checks
+	}
+
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := f.Name
+		if ns != "" {
+			name = ns + "." + f.Name
+		}
+		if rules := f.Tag.Get(asyncTag); rules != "" && !failed[name] {
+			checks = append(checks, asyncCheck{Namespace: name, Value: val.Field(i), Rules: strings.Split(rules, ",")})
+		}
+		checks = collectAsyncChecks(val.Field(i), name, failed, checks)
+	}
+	return checks
+}
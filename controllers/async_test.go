@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+type asyncExample struct {
+	Slow    string `async:"slow"`
+	Broken  string `async:"broken"`
+	Visitor string `async:"exists=visitors"`
+	Items   []asyncItemExample
+}
+
+type asyncItemExample struct {
+	Name string `async:"available=names"`
+}
+
+func TestAsyncChecks(t *testing.T) {
+	store := Store.(*MemoryLookup)
+	store.Add("visitors", "v1")
+	store.Add("names", "taken")
+	oldTimeout := AsyncTimeout
+	AsyncTimeout = 50 * time.Millisecond
+	defer func() {
+		store.Remove("visitors", "v1")
+		store.Remove("names", "taken")
+		AsyncTimeout = oldTimeout
+	}()
+
+	RegisterAsyncValidation("slow", func(ctx context.Context, value interface{}, param string) (bool, error) {
+		select {
+		case <-time.After(time.Second):
+			return true, nil
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}, "{field} is slow")
+	RegisterAsyncValidation("broken", func(ctx context.Context, value interface{}, param string) (bool, error) {
+		return false, errors.New("connection refused")
+	}, "{field} is broken")
+
+	t.Run("Results", func(t *testing.T) {
+		obj := asyncExample{
+			Visitor: "v2",
+			Items:   []asyncItemExample{{Name: "free"}, {Name: "taken"}},
+		}
+		start := time.Now()
+		errs := runAsyncChecks(context.Background(), &obj, map[string]bool{})
+		// the checks run side by side, so the slow one only costs the timeout once
+		assert.T(t, time.Since(start) < time.Second)
+
+		got := map[string]string{}
+		for _, fe := range errs.Errors {
+			got[fe.Namespace] = fe.Tag
+		}
+		assert.Equal(t, map[string]string{
+			"Slow":          tagAsyncTimeout,
+			"Broken":        tagAsyncUnavailable,
+			"Visitor":       "exists",
+			"Items[1].Name": "available",
+		}, got)
+	})
+	t.Run("SkipsFailedFields", func(t *testing.T) {
+		obj := asyncExample{Visitor: "v1"}
+		errs := runAsyncChecks(context.Background(), &obj, map[string]bool{"Slow": true, "Broken": true})
+		assert.Equal(t, (*asyncErrors)(nil), errs)
+	})
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		obj := asyncExample{Visitor: "v1"}
+		errs := runAsyncChecks(ctx, &obj, map[string]bool{"Slow": true, "Broken": true})
+		assert.Equal(t, 1, len(errs.Errors))
+		assert.Equal(t, tagAsyncTimeout, errs.Errors[0].Tag)
+	})
+}
+
+func TestPostPasswordAsync(t *testing.T) {
+	store := Store.(*MemoryLookup)
+	store.Add("usernames", "takenname")
+	defer store.Remove("usernames", "takenname")
+
+	t.Run("PasswordAsyncTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "username-taken",
+				Path:        "/password",
+				ExpCode:     400,
+				ExpFields:   []string{"Username"},
+				ExpMessages: []string{"Username is already taken"},
+				Body: models.PasswordExample{
+					Username:        "takenname",
+					Password:        "Tr0ub4dor&3",
+					PasswordConfirm: "Tr0ub4dor&3",
+					OldPassword:     "oldtestpass",
+				},
+			},
+			testCase{
+				Name:        "username-taken-and-bad-password",
+				Path:        "/password",
+				ExpCode:     400,
+				ExpFields:   []string{"Username", "PasswordConfirm"},
+				ExpMessages: []string{"Username is already taken", "Password confirm must match Password"},
+				Body: models.PasswordExample{
+					Username:        "takenname",
+					Password:        "Tr0ub4dor&3",
+					PasswordConfirm: "Tr0ub4dor&4",
+					OldPassword:     "oldtestpass",
+				},
+			},
+		}
+		runTests(t, tests, GetRouter())
+	})
+}
@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// DefaultBatchLimit is the most records a batch can have, unless the
+// router is built WithBatchLimit.
+const DefaultBatchLimit = 1000
+
+// batchWindow is how much of the end of a batch is kept, to place a
+// syntax error in it.
+const batchWindow = 64 << 10
+
+// BatchSuffix is added to a route's path for its batch variant, ex: /lead/batch
+const BatchSuffix = "/batch"
+
+// BatchResult is the response to a batch - how many records were checked
+// and the errors for each one that failed, by its index in the batch.
+type BatchResult struct {
+	Total   int          `json:"total"`
+	Valid   int          `json:"valid"`
+	Invalid int          `json:"invalid"`
+	Errors  []BatchError `json:"errors"`
+}
+
+// BatchError is the error map for a single record in a batch, in the same
+// format mwParseValidation writes for a single request.
+type BatchError struct {
+	Index  int               `json:"index"`
+	Errors map[string]string `json:"errors"`
+}
+
+// BatchHandler will return a handler that validates a JSON array, or
+// newline delimited JSON, of T's - each record goes through the same
+// binding and async checks as BindHandler. It responds with a BatchResult,
+// 200 if every record is valid or 400 if any aren't. With ?failFast=true
+// it stops at the first invalid record.
+//
+// ex: r.POST("/lead/batch", BatchHandler[models.LeadSourceExample]())
+func BatchHandler[T any]() gin.HandlerFunc {
+	var model T
+	registerModel(model)
+
+	return func(c *gin.Context) {
+		skipRecording(c.Request)
+		failFast, _ := strconv.ParseBool(c.Query("failFast"))
+		records, err := newBatchReader(c.Request.Body)
+		if err != nil {
+			recordBindError(c, err)
+			c.Set("controllerError", true)
+			return
+		}
+
+		loc := NegotiateLocale(c.GetHeader("Accept-Language"))
+		paths := pathFormat(c)
+		limit := batchLimit(c)
+		res := BatchResult{Errors: []BatchError{}}
+		for i := 0; !failFast || res.Invalid == 0; i++ {
+			raw, err := records.next()
+			if err == io.EOF {
+				break
+			}
+			if i == limit {
+				recordBindError(c, &limitError{Tag: tagBatchLimit, Limit: limit})
+				c.Set("controllerError", true)
+				return
+			}
+
+			res.Total++
+			if err != nil {
+				// the rest of the batch can't be read
+				res.Invalid++
+				res.Errors = append(res.Errors, BatchError{Index: i, Errors: problemMap(fieldProblems(err, nil, loc, paths))})
+				break
+			}
+			if err := validateRecord[T](c.Request.Context(), jsonBody(c), raw); err != nil {
+				res.Invalid++
+				res.Errors = append(res.Errors, BatchError{Index: i, Errors: problemMap(fieldProblems(err, raw, loc, paths))})
+				continue
+			}
+			res.Valid++
+		}
+
+		c.Header("Content-Language", loc.Locale)
+		if res.Invalid > 0 {
+			c.JSON(http.StatusBadRequest, res)
+			return
+		}
+		c.JSON(http.StatusOK, res)
+	}
+}
+
+// batchLimit will return the most records a batch can have on the router.
+func batchLimit(c *gin.Context) int {
+	if limit, ok := c.Get("batchLimit"); ok && limit.(int) > 0 {
+		return limit.(int)
+	}
+	return DefaultBatchLimit
+}
+
+// limitError is returned when a batch or CSV has more than Limit records.
+// Tag picks the message.
+type limitError struct {
+	Tag   string
+	Limit int
+}
+
+func (e *limitError) Error() string {
+	return fmt.Sprintf("%s: more than %d records", e.Tag, e.Limit)
+}
+
+// validateRecord will bind and validate a single record from a batch.
+func validateRecord[T any](ctx context.Context, b binding.BindingBody, raw []byte) error {
+	var v T
+	return withAsyncChecks(ctx, &v, b.BindBody(raw, &v))
+}
+
+// withAsyncChecks will run the async checks for obj once it's been bound
+// with the given error, the same way BindHandler does.
+func withAsyncChecks(ctx context.Context, obj interface{}, err error) error {
+	if failed, ok := failedFields(err); ok {
+		if errs := runAsyncChecks(ctx, obj, failed); errs != nil {
+			return errs
+		}
+	}
+	return err
+}
+
+// problemMap will turn the problems into the flat {"Field": "message"}
+// map mwParseValidation writes by default.
+func problemMap(problems []fieldProblem) map[string]string {
+	ret := map[string]string{}
+	for _, fp := range problems {
+		ret[fp.Field] = fp.Message
+	}
+	return ret
+}
+
+// batchReader reads the records in a batch one at a time, so a big batch
+// doesn't have to be held in memory.
+type batchReader struct {
+	dec   *json.Decoder
+	array bool
+	// tail is the end of what's been read, to place a syntax error
+	tail *tailBuffer
+	// skipped is the whitespace read before the decoder started, which
+	// its offsets don't count
+	skipped int64
+}
+
+// newBatchReader will work out whether the body is a JSON array or
+// newline delimited JSON from its first character.
+func newBatchReader(body io.Reader) (*batchReader, error) {
+	if body == nil {
+		return nil, io.EOF
+	}
+	tail := &tailBuffer{keep: batchWindow}
+	r := bufio.NewReader(io.TeeReader(body, tail))
+	br := &batchReader{dec: json.NewDecoder(r), tail: tail}
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			br.skipped++
+			continue
+		}
+		_ = r.UnreadByte()
+		if b == '[' {
+			br.array = true
+			_, err = br.dec.Token()
+			return br, br.placed(err)
+		}
+		return br, nil
+	}
+}
+
+// next will return the next record, or io.EOF once they've all been read.
+func (br *batchReader) next() (json.RawMessage, error) {
+	if br.array && !br.dec.More() {
+		if _, err := br.dec.Token(); err != nil {
+			if err == io.EOF {
+				// the array was never closed
+				return nil, br.placed(io.ErrUnexpectedEOF)
+			}
+			return nil, br.placed(err)
+		}
+		return nil, io.EOF
+	}
+	var raw json.RawMessage
+	if err := br.dec.Decode(&raw); err != nil {
+		if err == io.EOF && br.array {
+			return nil, br.placed(io.ErrUnexpectedEOF)
+		}
+		return nil, br.placed(err)
+	}
+	return raw, nil
+}
+
+// placed will turn a syntax error into one that says where it is, since
+// the batch isn't kept around to work it out later.
+func (br *batchReader) placed(err error) error {
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		return &syntaxError{Position: br.tail.position(br.skipped + e.Offset)}
+	}
+	if err == io.ErrUnexpectedEOF {
+		return &syntaxError{Position: br.tail.position(br.tail.size() + 1)}
+	}
+	return err
+}
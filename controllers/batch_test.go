@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestBatch(t *testing.T) {
+	const (
+		valid   = `{"VisitorID": "f6a91ca9-a517-458a-80f1-2e31b58f9cc2", "Source": "google"}`
+		invalid = `{"VisitorID": "f6a91ca9-a517-458a-80f1-2e31b58f9cc2", "Source": "other"}`
+	)
+	postBatch := func(t *testing.T, path string, body string, opts ...RouterOption) (int, BatchResult, map[string]string) {
+		bs := []byte(body)
+		req := performRequest(GetRouter(opts...), "POST", path, &bs, map[string]string{"Content-Type": "application/json"})
+		res := BatchResult{}
+		json.Unmarshal(req.Body.Bytes(), &res)
+		flat := map[string]string{}
+		json.Unmarshal(req.Body.Bytes(), &flat)
+		return req.Code, res, flat
+	}
+
+	t.Run("every record valid", func(t *testing.T) {
+		code, res, _ := postBatch(t, "/lead/batch", "["+valid+","+valid+"]")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, BatchResult{Total: 2, Valid: 2, Errors: []BatchError{}}, res)
+	})
+
+	t.Run("errors are by index", func(t *testing.T) {
+		code, res, _ := postBatch(t, "/lead/batch", "["+valid+","+invalid+","+`{"VisitorID": 5}`+"]")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, 3, res.Total)
+		assert.Equal(t, 1, res.Valid)
+		assert.Equal(t, 2, res.Invalid)
+		assert.Equal(t, []BatchError{
+			{Index: 1, Errors: map[string]string{"SourceDetail": "Source detail is required when Source is other"}},
+			{Index: 2, Errors: map[string]string{"VisitorID": "Visitor ID must be of type string"}},
+		}, res.Errors)
+	})
+
+	t.Run("newline delimited", func(t *testing.T) {
+		code, res, _ := postBatch(t, "/lead/batch", valid+"\n"+invalid+"\n"+valid+"\n")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, 3, res.Total)
+		assert.Equal(t, 1, res.Errors[0].Index)
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		code, res, _ := postBatch(t, "/lead/batch?failFast=true", "["+invalid+","+valid+","+invalid+"]")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, 1, res.Total)
+		assert.Equal(t, 1, len(res.Errors))
+	})
+
+	t.Run("unreadable record stops the batch", func(t *testing.T) {
+		code, res, _ := postBatch(t, "/lead/batch", "["+valid+",")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, 2, res.Total)
+		_, ok := res.Errors[0].Errors[bodyField]
+		assert.T(t, ok, res.Errors)
+	})
+
+	t.Run("records that aren't objects", func(t *testing.T) {
+		code, res, _ := postBatch(t, "/lead/batch", "[1,2]")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, 2, res.Invalid)
+		assert.Equal(t, map[string]string{bodyField: "Request body must be a JSON object"}, res.Errors[0].Errors)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		code, _, flat := postBatch(t, "/lead/batch", "")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Request body is required", flat[bodyField])
+	})
+
+	t.Run("too many records", func(t *testing.T) {
+		code, _, flat := postBatch(t, "/lead/batch", "["+valid+","+valid+","+valid+"]", WithBatchLimit(2))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+		assert.Equal(t, map[string]string{bodyField: "A batch can't have more than 2 records"}, flat)
+
+		bs := []byte("[" + valid + "," + valid + "," + valid + "]")
+		req := performRequest(GetRouter(WithBatchLimit(2)), "POST", "/lead/batch", &bs, map[string]string{"Content-Type": "application/json", "Accept-Language": "de"})
+		flat = map[string]string{}
+		json.Unmarshal(req.Body.Bytes(), &flat)
+		assert.Equal(t, "Ein Stapel darf höchstens 2 Datensätze enthalten", flat[bodyField])
+
+		req = performRequest(GetRouter(WithBatchLimit(2), WithProblemDetails()), "POST", "/lead/batch", &bs, map[string]string{"Content-Type": "application/json"})
+		assert.Equal(t, http.StatusRequestEntityTooLarge, req.Code)
+		assert.Equal(t, ProblemContentType, req.Header().Get("Content-Type"))
+		var p Problem
+		json.Unmarshal(req.Body.Bytes(), &p)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, p.Status)
+		assert.Equal(t, []InvalidParam{{
+			Name:   bodyField,
+			Tag:    "batch_limit",
+			Param:  "2",
+			Reason: "A batch can't have more than 2 records",
+			Code:   "validation.batch_limit",
+		}}, p.InvalidParams)
+	})
+
+	t.Run("lots of leading whitespace", func(t *testing.T) {
+		space := strings.Repeat(" \n", 4096)
+		code, res, _ := postBatch(t, "/lead/batch", space+"["+valid+","+valid+"]")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 2, res.Valid)
+
+		code, res, _ = postBatch(t, "/lead/batch", space+"["+valid+",]")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, map[string]string{bodyField: "Request body is not valid JSON at 4097:" + strconv.Itoa(len(valid)+2)}, res.Errors[0].Errors)
+	})
+
+	t.Run("syntax error far into a big batch", func(t *testing.T) {
+		records := strings.Repeat(valid+",\n", 2000)
+		code, res, _ := postBatch(t, "/lead/batch", "[\n"+records+`{"VisitorID": }`+"]", WithBatchLimit(3000))
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, 2001, res.Total)
+		assert.Equal(t, map[string]string{bodyField: "Request body is not valid JSON at 2002:15"}, res.Errors[0].Errors)
+	})
+}
@@ -0,0 +1,448 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// bodyField is the key used for errors about the request body as a whole.
+const bodyField = "body"
+
+// The tags used for bind failures that happen before validation - these
+// have templates in the catalog like any other tag.
+const (
+	tagBodyEmpty   = "body_empty"
+	tagBodyInvalid = "body_invalid"
+	tagBodyType    = "body_type"
+	tagJSONSyntax  = "json_syntax"
+	tagJSONType    = "json_type"
+	tagJSONUnknown = "json_unknown"
+	tagCSVSyntax   = "csv_syntax"
+	tagBatchLimit  = "batch_limit"
+	tagCSVLimit    = "csv_limit"
+)
+
+// StrictJSON is gin's JSON binding, except the body has to be one JSON
+// object and fields the model doesn't have are an error instead of being
+// ignored. Routes opt in to it with WithUnknownFieldsRejected or
+// RejectUnknownFields, or it can be used directly, ex:
+// c.MustBindWith(&car, StrictJSON)
+var StrictJSON binding.BindingBody = jsonBinding{rejectUnknown: true}
+
+// objectJSON is StrictJSON without the unknown field check, which is what
+// JSON bodies are bound with unless the route opted in.
+var objectJSON binding.BindingBody = jsonBinding{}
+
+type jsonBinding struct {
+	rejectUnknown bool
+}
+
+func (jsonBinding) Name() string {
+	return "json"
+}
+
+func (b jsonBinding) Bind(req *http.Request, obj interface{}) error {
+	if req == nil || req.Body == nil {
+		return io.EOF
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	return b.BindBody(body, obj)
+}
+
+func (b jsonBinding) BindBody(body []byte, obj interface{}) error {
+	if err := decodeJSON(body, obj, b.rejectUnknown); err != nil {
+		return err
+	}
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// RejectUnknownFields will make keys in a JSON body that the model doesn't
+// have an error on the routes it's used on, ex:
+// r.POST("/car", RejectUnknownFields(), carHandler)
+func RejectUnknownFields() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("rejectUnknown", true)
+		c.Next()
+	}
+}
+
+// jsonBody will return the binding for a JSON body on the route, which
+// only rejects unknown fields if the route opted in.
+func jsonBody(c *gin.Context) binding.BindingBody {
+	if c.GetBool("rejectUnknown") {
+		return StrictJSON
+	}
+	return objectJSON
+}
+
+// decodeJSON will decode the body into obj the way encoding/json does,
+// except the body has to be a single JSON value and, with rejectUnknown,
+// can't have keys obj doesn't.
+func decodeJSON(body []byte, obj interface{}, rejectUnknown bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(obj); err != nil {
+		return err
+	}
+	if err := noTrailingData(decoder); err != nil {
+		return err
+	}
+	if !rejectUnknown {
+		return nil
+	}
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return err
+	}
+	if unknown := unknownFields(reflect.TypeOf(obj), doc, ""); len(unknown) > 0 {
+		return &unknownFieldError{Namespaces: unknown}
+	}
+	return nil
+}
+
+// unknownFieldError is returned when the body has keys the model doesn't,
+// with where each one was, ex: Items[1].extra
+type unknownFieldError struct {
+	Namespaces []string
+}
+
+func (e *unknownFieldError) Error() string {
+	return "json: unknown field(s) " + strings.Join(e.Namespaces, ", ")
+}
+
+var jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields will return the keys in doc that t doesn't have a field
+// for, written with the keys as they were sent. A type with its own
+// UnmarshalJSON decides for itself what it accepts.
+func unknownFields(t reflect.Type, doc interface{}, ns string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshaler) {
+		return nil
+	}
+	unknown := []string{}
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			switch t.Kind() {
+			case reflect.Map:
+				unknown = append(unknown, unknownFields(t.Elem(), v[k], ns+"["+k+"]")...)
+			case reflect.Struct:
+				key := k
+				if ns != "" {
+					key = ns + "." + k
+				}
+				f, ok := jsonField(t, k)
+				if !ok {
+					unknown = append(unknown, key)
+					continue
+				}
+				unknown = append(unknown, unknownFields(f.Type, v[k], key)...)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, item := range v {
+				unknown = append(unknown, unknownFields(t.Elem(), item, fmt.Sprintf("%s[%d]", ns, i))...)
+			}
+		}
+	}
+	return unknown
+}
+
+// jsonField will return the field of t that encoding/json would decode the
+// key into - an exact match, then one ignoring case, looking through
+// embedded structs the way it does.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	var folded *reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if ef, ok := jsonField(ft, key); ok {
+					return ef, true
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if name == key {
+			return f, true
+		}
+		if folded == nil && strings.EqualFold(name, key) {
+			folded = &f
+		}
+	}
+	if folded != nil {
+		return *folded, true
+	}
+	return reflect.StructField{}, false
+}
+
+// noTrailingData will make sure there's nothing but whitespace left in
+// the body after the value the decoder just read.
+func noTrailingData(decoder *json.Decoder) error {
+	buffered, _ := ioutil.ReadAll(decoder.Buffered())
+	offset := decoder.InputOffset() + int64(len(buffered)-len(bytes.TrimLeft(buffered, " \t\r\n"))) + 1
+	if _, err := decoder.Token(); err != io.EOF {
+		return &trailingDataError{Offset: offset}
+	}
+	return nil
+}
+
+// trailingDataError is returned when there's more after the JSON value in
+// a body, ex: {"Make": "abc"} trailing. Offset is counted the same way as
+// a json.SyntaxError's.
+type trailingDataError struct {
+	Offset int64
+}
+
+func (e *trailingDataError) Error() string {
+	return fmt.Sprintf("invalid character after top-level value at offset %d", e.Offset)
+}
+
+// typeError is returned when a path, query or form value can't be
+// converted to its field's type. Field is the Go field name when Model is
+// set, so the path can be resolved like a validation error's.
+type typeError struct {
+	Field string
+	Type  reflect.Type
+	Model reflect.Type
+}
+
+func (e *typeError) Error() string {
+	return fmt.Sprintf("%s: cannot convert to %s", e.Field, e.Type)
+}
+
+// bodyRecorder keeps a copy of everything read from the request body, so
+// we can point at where a syntax error happened.
+type bodyRecorder struct {
+	io.Reader
+	io.Closer
+	body io.ReadCloser
+	buf  *bytes.Buffer
+}
+
+// recordBody will swap the request body for one that remembers what's
+// been read from it. A multipart body is left alone - it's mostly files
+// and there's no JSON in it to point into.
+func recordBody(req *http.Request) *bodyRecorder {
+	rec := &bodyRecorder{buf: &bytes.Buffer{}}
+	if req.Body != nil && !strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		rec.Reader = io.TeeReader(req.Body, rec.buf)
+		rec.Closer = req.Body
+		rec.body = req.Body
+		req.Body = rec
+	}
+	return rec
+}
+
+// skipRecording will stop the body from being copied as it's read, for
+// handlers that stream a body too big to keep, ex: a CSV upload.
+func skipRecording(req *http.Request) {
+	if rec, ok := req.Body.(*bodyRecorder); ok {
+		req.Body = rec.body
+	}
+}
+
+// Bytes will return what's been read from the body so far.
+func (r *bodyRecorder) Bytes() []byte {
+	return r.buf.Bytes()
+}
+
+// bindErrorToFieldErrors will classify an error from binding the request
+// body and turn it into field errors, so it can be rendered like any other
+// validation error.
+func bindErrorToFieldErrors(err error, body []byte) []*fieldError {
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		return []*fieldError{bodyError(tagJSONSyntax, position(body, e.Offset))}
+	case *trailingDataError:
+		return []*fieldError{bodyError(tagJSONSyntax, position(body, e.Offset))}
+	case *syntaxError:
+		return []*fieldError{bodyError(tagJSONSyntax, e.Position)}
+	case *limitError:
+		return []*fieldError{bodyError(e.Tag, strconv.Itoa(e.Limit))}
+	case *json.UnmarshalTypeError:
+		if e.Field == "" {
+			// the body is an array or a scalar, not an object
+			return []*fieldError{bodyError(tagBodyType, "")}
+		}
+		return []*fieldError{&fieldError{
+			Namespace: jsonFieldNamespace(e.Field),
+			Tag:       tagJSONType,
+			Param:     jsonTypeName(e.Type),
+			Kind:      e.Type.Kind(),
+			Type:      e.Type,
+		}}
+	case *unknownFieldError:
+		ret := []*fieldError{}
+		for _, ns := range e.Namespaces {
+			ret = append(ret, &fieldError{Namespace: ns, Tag: tagJSONUnknown, Kind: reflect.Invalid})
+		}
+		return ret
+	case *typeError:
+		return []*fieldError{&fieldError{
+			Namespace: e.Field,
+			Tag:       tagJSONType,
+			Param:     jsonTypeName(e.Type),
+			Kind:      e.Type.Kind(),
+			Type:      e.Type,
+		}}
+	}
+
+	switch {
+	case err == io.EOF:
+		return []*fieldError{bodyError(tagBodyEmpty, "")}
+	case err == io.ErrUnexpectedEOF:
+		return []*fieldError{bodyError(tagJSONSyntax, position(body, int64(len(body))+1))}
+	}
+	return []*fieldError{bodyError(tagBodyInvalid, "")}
+}
+
+// bodyError will return a field error about the body as a whole.
+func bodyError(tag string, param string) *fieldError {
+	return &fieldError{Namespace: bodyField, Tag: tag, Param: param, Kind: reflect.Invalid}
+}
+
+// position will return the line:column of the given offset into the body,
+// counting from 1 like an editor would.
+func position(body []byte, offset int64) string {
+	return (&tailBuffer{buf: body}).position(offset)
+}
+
+// syntaxError is a JSON syntax error that's already been placed, for
+// bodies that weren't kept whole, ex: a batch.
+type syntaxError struct {
+	Position string
+}
+
+func (e *syntaxError) Error() string {
+	return "invalid JSON at " + e.Position
+}
+
+// tailBuffer keeps the end of what's written to it, and counts the lines
+// in what it's let go of, so it can still place an offset near the end.
+type tailBuffer struct {
+	buf []byte
+	// keep is how much to hold on to, or 0 for everything
+	keep int
+	// dropped is how many bytes have been let go of, lines how many
+	// newlines were in them and lineStart the offset the last line began at
+	dropped   int64
+	lines     int
+	lineStart int64
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if t.keep > 0 && len(t.buf) > 2*t.keep {
+		drop := t.buf[:len(t.buf)-t.keep]
+		if i := bytes.LastIndexByte(drop, '\n'); i >= 0 {
+			t.lines += bytes.Count(drop, []byte("\n"))
+			t.lineStart = t.dropped + int64(i) + 1
+		}
+		t.dropped += int64(len(drop))
+		t.buf = append(t.buf[:0], t.buf[len(drop):]...)
+	}
+	return len(p), nil
+}
+
+// size will return how many bytes have been written.
+func (t *tailBuffer) size() int64 {
+	return t.dropped + int64(len(t.buf))
+}
+
+// position will return the line:column of the given offset into what's
+// been written. An offset that's been let go of is placed at the start of
+// what's left.
+func (t *tailBuffer) position(offset int64) string {
+	end := offset - t.dropped
+	if end < 0 {
+		offset, end = t.dropped, 0
+	}
+	if end > int64(len(t.buf)) {
+		end = int64(len(t.buf))
+	}
+	before := t.buf[:end]
+	line := t.lines + bytes.Count(before, []byte("\n")) + 1
+	lineStart := t.lineStart
+	if i := bytes.LastIndexByte(before, '\n'); i >= 0 {
+		lineStart = t.dropped + int64(i) + 1
+	}
+	return fmt.Sprintf("%d:%d", line, offset-lineStart)
+}
+
+// jsonFieldNamespace will turn the path encoding/json reports for a type
+// error (ex: Items.1.Name) into one errorPath understands (Items[1].Name).
+func jsonFieldNamespace(field string) string {
+	b := strings.Builder{}
+	for i, part := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(part); err == nil && i > 0 {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// jsonTypeName will return the name of the JSON type that decodes into
+// the given Go type.
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	return "object"
+}
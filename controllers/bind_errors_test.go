@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestBindErrors(t *testing.T) {
+	t.Run("BindErrorTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "malformed-json",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"body"},
+				ExpMessages: []string{"Request body is not valid JSON at 3:11"},
+				RawBody:     "{\n  \"Make\": \"test make\",\n  \"Model\" \"test model\"\n}",
+			},
+			testCase{
+				Name:        "truncated-json",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"body"},
+				ExpMessages: []string{"Request body is not valid JSON at 1:9"},
+				RawBody:     `{"Make":`,
+			},
+			testCase{
+				Name:        "type-mismatch",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"Make"},
+				ExpMessages: []string{"Make must be of type string"},
+				RawBody:     `{"Make": 5, "Model": "test model"}`,
+			},
+			testCase{
+				Name:        "nested-type-mismatch",
+				Path:        "/album",
+				ExpCode:     400,
+				ExpFields:   []string{"Artist[1]"},
+				ExpMessages: []string{"Artist entry 2 must be of type string"},
+				RawBody:     `{"Artist": ["blink 182", 182], "Name": "dude ranch"}`,
+			},
+			testCase{
+				Name:        "top-level-array",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"body"},
+				ExpMessages: []string{"Request body must be a JSON object"},
+				RawBody:     "[]",
+			},
+			testCase{
+				Name:        "top-level-scalar",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"body"},
+				ExpMessages: []string{"Request body must be a JSON object"},
+				RawBody:     "5",
+			},
+			testCase{
+				Name:    "unknown-field-ignored",
+				Path:    "/car",
+				ExpCode: 200,
+				RawBody: `{"Make": "abcd", "Model": "abcd", "Bogus": 1}`,
+			},
+			testCase{
+				Name:        "trailing-data",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"body"},
+				ExpMessages: []string{"Request body is not valid JSON at 1:29"},
+				RawBody:     `{"Make":"abc","Model":"de"} trailing`,
+			},
+			testCase{
+				Name:        "trailing-value",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"body"},
+				ExpMessages: []string{"Request body is not valid JSON at 2:1"},
+				RawBody:     "{\"Make\":\"abc\",\"Model\":\"de\"}\n{}",
+			},
+			testCase{
+				Name:    "trailing-whitespace",
+				Path:    "/car",
+				ExpCode: 200,
+				RawBody: "{\"Make\":\"abc\",\"Model\":\"de\"}\n\t ",
+			},
+			testCase{
+				Name:        "empty-body",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"body"},
+				ExpMessages: []string{"Request body is required"},
+				RawBody:     " ",
+			},
+		}
+		runTests(t, tests, GetRouter())
+	})
+	t.Run("UnknownFieldsRejected", func(t *testing.T) {
+		body := `{"Make": "abcd", "Model": "abcd", "Bogus": 1}`
+		runTests(t, []testCase{
+			testCase{
+				Name:        "opted-in",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"Bogus"},
+				ExpMessages: []string{"Bogus is not a recognized field"},
+				RawBody:     body,
+			},
+			testCase{
+				Name:    "other-route",
+				Path:    "/album",
+				ExpCode: 200,
+				RawBody: `{"Artist": ["blink 182"], "Name": "dude ranch", "Bogus": 1}`,
+			},
+		}, GetRouter(WithUnknownFieldsRejected("/car")))
+		runTests(t, []testCase{
+			testCase{
+				Name:      "every-route",
+				Path:      "/album",
+				ExpCode:   400,
+				ExpFields: []string{"Bogus"},
+				RawBody:   `{"Artist": ["blink 182"], "Name": "dude ranch", "Bogus": 1}`,
+			},
+		}, GetRouter(WithUnknownFieldsRejected()))
+	})
+}
+
+func TestStrictJSON(t *testing.T) {
+	binding.Validator = modelValidator
+	r := gin.New()
+	r.Use(mwParseValidation(&routerConfig{paths: DefaultPathFormat}))
+	r.POST("/car", func(c *gin.Context) {
+		var car models.CarExample
+		if err := c.MustBindWith(&car, StrictJSON); err != nil {
+			c.Set("controllerError", true)
+			return
+		}
+		c.Status(200)
+	})
+	r.POST("/order", func(c *gin.Context) {
+		var order struct {
+			Items []struct {
+				Name string `json:"name"`
+			}
+			Notes map[string]struct{ Text string }
+		}
+		if err := c.MustBindWith(&order, StrictJSON); err != nil {
+			c.Set("controllerError", true)
+			return
+		}
+		c.Status(200)
+	})
+
+	runTests(t, []testCase{
+		testCase{
+			Name:        "unknown-field",
+			Path:        "/car",
+			ExpCode:     400,
+			ExpFields:   []string{"Color"},
+			ExpMessages: []string{"Color is not a recognized field"},
+			RawBody:     `{"Make": "test make", "Model": "test model", "Color": "red"}`,
+		},
+		testCase{
+			Name:        "nested-unknown-fields",
+			Path:        "/order",
+			ExpCode:     400,
+			ExpFields:   []string{"items[1].price", "Notes[a].Bold"},
+			ExpMessages: []string{"Items entry 2 price is not a recognized field", "Notes 'a' bold is not a recognized field"},
+			RawBody:     `{"items": [{"name": "a"}, {"NAME": "b", "price": 1}], "Notes": {"a": {"text": "x", "Bold": true}}}`,
+		},
+		testCase{
+			Name:    "known-fields",
+			Path:    "/car",
+			ExpCode: 200,
+			Body:    models.CarExample{Make: "test make", Model: "test model"},
+		},
+	}, r)
+}
+
+func TestRecordBody(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/car", strings.NewReader(`{"Make": "abc"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := recordBody(req)
+		_, _ = ioutil.ReadAll(req.Body)
+		assert.Equal(t, `{"Make": "abc"}`, string(rec.Bytes()))
+	})
+	t.Run("MultipartSkipped", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/profile", strings.NewReader("--x\r\n\r\nfile\r\n--x--"))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+		rec := recordBody(req)
+		_, _ = ioutil.ReadAll(req.Body)
+		assert.Equal(t, 0, len(rec.Bytes()))
+	})
+}
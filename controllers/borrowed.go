@@ -2,13 +2,11 @@ package controllers
 
 import (
 	"errors"
-	"fmt"
-	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
-	"gopkg.in/go-playground/validator.v8"
+	"github.com/go-playground/validator/v10"
 )
 
 // Below is borrowed from some very kind stranger.
@@ -19,7 +17,7 @@ var (
 
 func UcFirst(str string) string {
 	for i, v := range str {
-		return string(unicode.ToUpper(v)) + str[i+1:]
+		return string(unicode.ToUpper(v)) + str[i+utf8.RuneLen(v):]
 	}
 	return ""
 }
@@ -28,6 +26,8 @@ func LcFirst(str string) string {
 	return strings.ToLower(str)
 }
 
+// Split will break a field name into words (ex: OldPassword -> Old password).
+// Messages use Humanize now, which keeps acronyms like ID intact.
 func Split(src string) string {
 	// don't split invalid utf8
 	if !utf8.ValidString(src) {
@@ -83,66 +83,17 @@ func Split(src string) string {
 
 // Unit will take in the field being validated and return
 // the appropriate string to use when describing the desired
-// amount of whatever is being validated.
-func Unit(e *validator.FieldError) string {
-	switch e.Kind.String() {
-	case "slice":
-		i, _ := strconv.Atoi(e.Param)
-		if i < 2 {
-			return "entry"
-		}
-		return "entries"
-	case "string":
-		i, _ := strconv.Atoi(e.Param)
-		if i < 2 {
-			return "character"
-		}
-		return "characters"
-	default:
-		return "unknown"
-	}
+// amount of whatever is being validated - characters for strings,
+// entries for slices and arrays, keys for maps, and nothing for numbers.
+func Unit(e validator.FieldError) string {
+	return Messages.localizer().unit(newFieldError(e))
 }
 
 // ValidationErrorToText will take a field error and return the
 // appropriate readable version of the error
-func ValidationErrorToText(e *validator.FieldError) string {
-	// NOTE: A case needs to be added to this for each tag
-	//       you implement - this is probably the best and
-	//       most obvious reason for consistency.
-	//
-	// EXPLANATIONS:
-	// - e.Field => this is the field being validated
-	// - word => this parses the field into readable form (ex: OldPassword -> Old password)
-	// - e.Param => this will be the value the field field is "supposed" to be, or the limit, etc
-
-	word := Split(e.Field)
-	switch e.Tag {
-	case "required":
-		return fmt.Sprintf("%s is required", word)
-	case "max":
-		return fmt.Sprintf("%s cannot be longer than %s", word, e.Param)
-	case "min":
-		return fmt.Sprintf("%s must be longer than %s", word, e.Param)
-	case "email":
-		return fmt.Sprintf("Invalid email format")
-	case "len":
-		return fmt.Sprintf("%s must be %s characters long", word, e.Param)
-	case "lte":
-		return fmt.Sprintf("%s must contain no more than %s %s", word, e.Param, Unit(e))
-	case "gte":
-		return fmt.Sprintf("%s must contain at least %s %s", word, e.Param, Unit(e))
-	case "alphanum":
-		return fmt.Sprintf("%s must be alphanumeric", word)
-	case "nefield":
-		return fmt.Sprintf("%s must not be the same as %s", word, Split(e.Param))
-	case "excludes":
-		return fmt.Sprintf("%s must not be '%s'", word, e.Param)
-	case "excludesrune":
-		return fmt.Sprintf("%s must not contain '%s'", word, e.Param)
-	case "eqfield":
-		return fmt.Sprintf("%s must match %s", word, e.Param)
-	case "uuid4":
-		return fmt.Sprintf("%s is not a valid uuidv4", word)
-	}
-	return fmt.Sprintf("%s is not valid", word)
+func ValidationErrorToText(e validator.FieldError) string {
+	// NOTE: The message for each tag lives in the Messages catalog - if
+	//       you implement a new tag, register a template for it there
+	//       or GetRouter will refuse to start.
+	return Messages.Render(e)
 }
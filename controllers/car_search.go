@@ -0,0 +1,9 @@
+package controllers
+
+import "github.com/mike-webster/golang-validation/models"
+
+// carSearchHandler will handle GET requests to /cars
+var carSearchHandler = BindHandler(FromRequest, respondOK[models.CarSearchExample])
+
+// carLookupHandler will handle GET requests to /cars/:id
+var carLookupHandler = BindHandler(FromRequest, respondOK[models.CarLookupExample])
@@ -0,0 +1,6 @@
+package controllers
+
+import "github.com/mike-webster/golang-validation/models"
+
+// coordinatesHandler will handle POST requests to /coordinates
+var coordinatesHandler = BindHandler(FromBody, respondOK[models.PostCoordinatesExample])
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestPostCoordinates(t *testing.T) {
+	t.Run("CoordinatesTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "nothing-provided",
+				Path:        "/coordinates",
+				ExpCode:     400,
+				ExpFields:   []string{"UserID", "Lat", "Long"},
+				ExpMessages: []string{"User ID is required", "Latitude is required", "Longitude is required"},
+				Body:        models.PostCoordinatesExample{},
+			},
+			testCase{
+				Name:        "user-id-negative",
+				Path:        "/coordinates",
+				ExpCode:     400,
+				ExpFields:   []string{"UserID"},
+				ExpMessages: []string{"User ID must be at least 1"},
+				Body: models.PostCoordinatesExample{
+					UserID: -3,
+					Lat:    "41.8781",
+					Long:   "-87.6298",
+				},
+			},
+			testCase{
+				Name:        "coordinates-out-of-range",
+				Path:        "/coordinates",
+				ExpCode:     400,
+				ExpFields:   []string{"Lat", "Long"},
+				ExpMessages: []string{"Latitude must be a valid latitude", "Longitude must be a valid longitude"},
+				Body: models.PostCoordinatesExample{
+					UserID: 1,
+					Lat:    "91.0",
+					Long:   "-187.6298",
+				},
+			},
+			testCase{
+				Name:    "success",
+				Path:    "/coordinates",
+				ExpCode: 200,
+				Body: models.PostCoordinatesExample{
+					UserID: 1,
+					Lat:    "41.8781",
+					Long:   "-87.6298",
+				},
+			},
+		}
+		runTests(t, tests, GetRouter())
+	})
+}
@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// csvTag names the column a field is read from, ex: `csv:"visitor_id"` -
+// otherwise the field's json (or form, xml, uri) name or Go name is used.
+// Headers match them however they're written, ex: visitor_id, Visitor ID
+// and VisitorID are all the same column.
+const csvTag = "csv"
+
+// CSVSuffix is added to a route's path for its CSV upload, ex: /lead/csv
+const CSVSuffix = "/csv"
+
+// CSVFormField is the multipart form field the CSV file is uploaded in.
+const CSVFormField = "file"
+
+// MIMECSV is the content type for a CSV file posted as the whole body.
+const MIMECSV = "text/csv"
+
+// CSVResult is the response to a CSV upload - how many rows were checked
+// and the errors for each one that failed.
+type CSVResult struct {
+	Rows    int           `json:"rows"`
+	Valid   int           `json:"valid"`
+	Invalid int           `json:"invalid"`
+	Errors  []CSVRowError `json:"errors"`
+}
+
+// CSVRowError is the errors for a single row, keyed by column. Row is the
+// line it starts on, counting the header as line 1 like a spreadsheet.
+type CSVRowError struct {
+	Row    int               `json:"row"`
+	Errors map[string]string `json:"errors"`
+}
+
+// ValidateCSV will read a CSV one row at a time, with a header row naming
+// the columns, and validate each row as a T. Only the current row is held
+// in memory. An error is returned if there's no header to read, or if
+// there are more than limit rows (DefaultBatchLimit if it's 0).
+func ValidateCSV[T any](ctx context.Context, r io.Reader, loc *Localizer, limit int) (CSVResult, error) {
+	if limit <= 0 {
+		limit = DefaultBatchLimit
+	}
+	res := CSVResult{Errors: []CSVRowError{}}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return res, err
+	}
+	header = append([]string{}, header...)
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	columns := csvColumns(typ, header)
+	keys := map[string]string{}
+	for i, fi := range columns {
+		if fi >= 0 {
+			keys[fieldKey(typ.Field(fi))] = header[i]
+		}
+	}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return res, nil
+		}
+		var perr *csv.ParseError
+		if err != nil && !errors.As(err, &perr) {
+			return res, err
+		}
+		if res.Rows == limit {
+			return res, &limitError{Tag: tagCSVLimit, Limit: limit}
+		}
+
+		res.Rows++
+		if perr != nil {
+			res.Invalid++
+			fe := bodyError(tagCSVSyntax, fmt.Sprintf("%d:%d", perr.Line, perr.Column))
+			res.Errors = append(res.Errors, CSVRowError{
+				Row:    perr.StartLine,
+				Errors: map[string]string{bodyField: loc.render(fe, errorPath(fe), nil)},
+			})
+			continue
+		}
+
+		var v T
+		if err := withAsyncChecks(ctx, &v, bindCSVRow(&v, header, columns, record)); err != nil {
+			row, _ := cr.FieldPos(0)
+			res.Invalid++
+			errs := map[string]string{}
+			for _, fp := range fieldProblems(err, nil, loc, DefaultPathFormat) {
+				if column, ok := keys[fp.Field]; ok {
+					fp.Field = column
+				}
+				errs[fp.Field] = fp.Message
+			}
+			res.Errors = append(res.Errors, CSVRowError{Row: row, Errors: errs})
+			continue
+		}
+		res.Valid++
+	}
+}
+
+// csvColumns will return the index of the field each column is read into,
+// or -1 for columns the model doesn't have.
+func csvColumns(t reflect.Type, header []string) []int {
+	columns := make([]int, len(header))
+	for i, name := range header {
+		columns[i] = -1
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		for fi := 0; fi < t.NumField(); fi++ {
+			f := t.Field(fi)
+			if f.PkgPath != "" {
+				continue
+			}
+			key := f.Tag.Get(csvTag)
+			if key == "" {
+				key = fieldKey(f)
+			}
+			if sameColumn(name, key) || sameColumn(name, f.Name) {
+				columns[i] = fi
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// sameColumn will compare column names by their words, ignoring case.
+func sameColumn(a string, b string) bool {
+	return strings.EqualFold(Humanize(a), Humanize(b))
+}
+
+// bindCSVRow will set the fields of obj from a row and validate it. Empty
+// cells leave the field as its zero value, so required can catch them.
+func bindCSVRow(obj interface{}, header []string, columns []int, record []string) error {
+	val := reflect.ValueOf(obj).Elem()
+	for i, cell := range record {
+		if i >= len(columns) || columns[i] < 0 || cell == "" {
+			continue
+		}
+		field := val.Field(columns[i])
+		if err := setField(field, cell); err != nil {
+			return &typeError{Field: header[i], Type: field.Type()}
+		}
+	}
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// CSVHandler will return a handler that validates a CSV of T's, uploaded
+// as the CSVFormField of a multipart form or posted as a text/csv body.
+// It responds with a CSVResult, 200 if every row is valid or 400 if any
+// aren't. The upload is streamed, so it's never all in memory at once, and
+// it can have as many rows as a batch can have records.
+//
+// ex: r.POST("/lead/csv", CSVHandler[models.LeadSourceExample]())
+func CSVHandler[T any]() gin.HandlerFunc {
+	var model T
+	registerModel(model)
+
+	return func(c *gin.Context) {
+		skipRecording(c.Request)
+		loc := NegotiateLocale(c.GetHeader("Accept-Language"))
+		var res CSVResult
+		file, err := csvUpload(c.Request)
+		if err == nil {
+			res, err = ValidateCSV[T](c.Request.Context(), file, loc, batchLimit(c))
+		}
+		if err != nil {
+			recordBindError(c, err)
+			c.Set("controllerError", true)
+			return
+		}
+
+		c.Header("Content-Language", loc.Locale)
+		if res.Invalid > 0 {
+			c.JSON(http.StatusBadRequest, res)
+			return
+		}
+		c.JSON(http.StatusOK, res)
+	}
+}
+
+// csvUpload will return the uploaded file, without reading the rest of
+// the form into memory.
+func csvUpload(req *http.Request) (io.Reader, error) {
+	if req.Body == nil {
+		return nil, io.EOF
+	}
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != gin.MIMEMultipartPOSTForm {
+		return req.Body, nil
+	}
+
+	mr, err := req.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			// there's no file in the form
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == CSVFormField {
+			return part, nil
+		}
+	}
+}
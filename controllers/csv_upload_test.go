@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestValidateCSV(t *testing.T) {
+	t.Run("errors are keyed by row and column", func(t *testing.T) {
+		csv := "visitor_id,source,source_detail\n" +
+			"f6a91ca9-a517-458a-80f1-2e31b58f9cc2,google,\n" +
+			"not-a-uuid,other,\n" +
+			"f6a91ca9-a517-458a-80f1-2e31b58f9cc2,yahoo,\n"
+		res, err := ValidateCSV[models.LeadSourceExample](context.Background(), strings.NewReader(csv), Messages.localizer(), 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 3, res.Rows)
+		assert.Equal(t, 2, res.Valid)
+		assert.Equal(t, []CSVRowError{{Row: 3, Errors: map[string]string{
+			"visitor_id":    "Visitor ID is not a valid uuidv4",
+			"source_detail": "Source detail is required when Source is other",
+		}}}, res.Errors)
+	})
+
+	t.Run("missing columns are required", func(t *testing.T) {
+		res, err := ValidateCSV[models.LeadSourceExample](context.Background(), strings.NewReader("Source\ngoogle\n"), Messages.localizer(), 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "Visitor ID is required", res.Errors[0].Errors["VisitorID"])
+	})
+
+	t.Run("cells that can't be converted", func(t *testing.T) {
+		type row struct {
+			Name  string `binding:"required"`
+			Count int    `binding:"gte=1"`
+		}
+		res, err := ValidateCSV[row](context.Background(), strings.NewReader("name,count\nwidgets,lots\n"), Messages.localizer(), 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, map[string]string{"count": "Count must be of type number"}, res.Errors[0].Errors)
+	})
+
+	t.Run("rows that aren't valid CSV", func(t *testing.T) {
+		res, err := ValidateCSV[models.LeadSourceExample](context.Background(), strings.NewReader("Source\n\"google\n"), Messages.localizer(), 0)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 2, res.Errors[0].Row)
+		_, ok := res.Errors[0].Errors[bodyField]
+		assert.T(t, ok, res.Errors)
+	})
+
+	t.Run("more rows than the limit", func(t *testing.T) {
+		csv := "Source\n" + strings.Repeat("bing\n", 3)
+		res, err := ValidateCSV[models.LeadSourceExample](context.Background(), strings.NewReader(csv), Messages.localizer(), 2)
+		assert.Equal(t, &limitError{Tag: tagCSVLimit, Limit: 2}, err)
+		assert.Equal(t, 2, res.Rows)
+		assert.Equal(t, 2, len(res.Errors))
+	})
+}
+
+func TestCSVUpload(t *testing.T) {
+	upload := func(t *testing.T, field string, csv string) (int, []byte) {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+		w.WriteField("note", "leads from the fair")
+		part, _ := w.CreateFormFile(field, "leads.csv")
+		part.Write([]byte(csv))
+		w.Close()
+		bs := body.Bytes()
+		req := performRequest(GetRouter(), "POST", "/lead/csv", &bs, map[string]string{"Content-Type": w.FormDataContentType()})
+		return req.Code, req.Body.Bytes()
+	}
+
+	t.Run("every row valid", func(t *testing.T) {
+		code, body := upload(t, CSVFormField, "VisitorID,Source\nf6a91ca9-a517-458a-80f1-2e31b58f9cc2,google\n")
+		assert.Equal(t, http.StatusOK, code)
+		res := CSVResult{}
+		json.Unmarshal(body, &res)
+		assert.Equal(t, CSVResult{Rows: 1, Valid: 1, Errors: []CSVRowError{}}, res)
+	})
+
+	t.Run("invalid row", func(t *testing.T) {
+		code, body := upload(t, CSVFormField, "VisitorID,Source\nf6a91ca9-a517-458a-80f1-2e31b58f9cc2,bing\n")
+		assert.Equal(t, http.StatusBadRequest, code)
+		res := CSVResult{}
+		json.Unmarshal(body, &res)
+		assert.Equal(t, []CSVRowError{{Row: 2, Errors: map[string]string{"Source": "Source is not valid"}}}, res.Errors)
+	})
+
+	t.Run("posted as text/csv", func(t *testing.T) {
+		bs := []byte("VisitorID,Source\nf6a91ca9-a517-458a-80f1-2e31b58f9cc2,google\n")
+		req := performRequest(GetRouter(), "POST", "/lead/csv", &bs, map[string]string{"Content-Type": MIMECSV})
+		assert.Equal(t, http.StatusOK, req.Code)
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		bs := []byte("VisitorID,Source\n" + strings.Repeat("f6a91ca9-a517-458a-80f1-2e31b58f9cc2,google\n", 3))
+		headers := map[string]string{"Content-Type": MIMECSV}
+		req := performRequest(GetRouter(WithBatchLimit(2)), "POST", "/lead/csv", &bs, headers)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, req.Code)
+		errs := map[string]string{}
+		json.Unmarshal(req.Body.Bytes(), &errs)
+		assert.Equal(t, map[string]string{bodyField: "A CSV can't have more than 2 rows"}, errs)
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		code, body := upload(t, "other", "VisitorID,Source\n")
+		assert.Equal(t, http.StatusBadRequest, code)
+		errs := map[string]string{}
+		json.Unmarshal(body, &errs)
+		assert.Equal(t, "Request body is required", errs[bodyField])
+	})
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DisposableEmailDomains are the domains rejected by notdisposableemail.
+var DisposableEmailDomains = map[string]bool{
+	"10minutemail.com":  true,
+	"getnada.com":       true,
+	"guerrillamail.com": true,
+	"mailinator.com":    true,
+	"maildrop.cc":       true,
+	"sharklasers.com":   true,
+	"tempmail.com":      true,
+	"throwawaymail.com": true,
+	"trashmail.com":     true,
+	"yopmail.com":       true,
+}
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+func init() {
+	tags := []struct {
+		tag      string
+		fn       validator.Func
+		template string
+	}{
+		{"strongpassword", isStrongPassword, "{field} must contain an uppercase letter, a lowercase letter, a number and a symbol"},
+		{"notdisposableemail", isNotDisposableEmail, "{field} must not be a disposable email address"},
+		{"slug", isSlug, "{field} must only contain lowercase letters, numbers and dashes"},
+	}
+	for _, t := range tags {
+		if err := RegisterValidation(t.tag, t.fn, t.template); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// isStrongPassword will check that the field has at least one uppercase
+// letter, lowercase letter, number and symbol.
+func isStrongPassword(fl validator.FieldLevel) bool {
+	var upper, lower, number, symbol bool
+	for _, r := range fl.Field().String() {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			number = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	return upper && lower && number && symbol
+}
+
+// isNotDisposableEmail will check that the field's domain isn't one of
+// the DisposableEmailDomains. It doesn't check that it's an email - use
+// the email tag for that.
+func isNotDisposableEmail(fl validator.FieldLevel) bool {
+	email := fl.Field().String()
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return true
+	}
+	return !DisposableEmailDomains[strings.ToLower(email[at+1:])]
+}
+
+// isSlug will check that the field is lowercase words separated by dashes.
+func isSlug(fl validator.FieldLevel) bool {
+	return slugRegex.MatchString(fl.Field().String())
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+type customTagsExample struct {
+	Email    string `binding:"required,email,notdisposableemail"`
+	Password string `binding:"required,strongpassword"`
+	Handle   string `binding:"required,slug"`
+	Color    string `binding:"omitempty,primarycolor"`
+}
+
+func isPrimaryColor(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case "red", "yellow", "blue":
+		return true
+	}
+	return false
+}
+
+func TestCustomTags(t *testing.T) {
+	if err := RegisterValidation("primarycolor", isPrimaryColor, "{field} must be a primary color"); err != nil {
+		t.Fatal(err)
+	}
+	binding.Validator = modelValidator
+	r := gin.New()
+	r.Use(mwParseValidation(&routerConfig{paths: DefaultPathFormat}))
+	r.POST("/custom", BindHandler(FromJSON, respondOK[customTagsExample]))
+
+	t.Run("CustomTagTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:    "valid",
+				Path:    "/custom",
+				ExpCode: 200,
+				Body: customTagsExample{
+					Email:    "mike@example.com",
+					Password: "Tr0ub4dor&3",
+					Handle:   "golang-validation",
+					Color:    "blue",
+				},
+			},
+			testCase{
+				Name:      "invalid",
+				Path:      "/custom",
+				ExpCode:   400,
+				ExpFields: []string{"Email", "Password", "Handle", "Color"},
+				ExpMessages: []string{
+					"Email must not be a disposable email address",
+					"Password must contain an uppercase letter, a lowercase letter, a number and a symbol",
+					"Handle must only contain lowercase letters, numbers and dashes",
+					"Color must be a primary color",
+				},
+				Body: customTagsExample{
+					Email:    "mike@Mailinator.com",
+					Password: "troubador3",
+					Handle:   "Golang Validation",
+					Color:    "green",
+				},
+			},
+		}
+		runTests(t, tests, r)
+	})
+}
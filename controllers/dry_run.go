@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ValidatePrefix is where GetRouter mounts the dry-run variant of each
+// route, by model name, ex: POST /validate/CarExample
+const ValidatePrefix = "/validate"
+
+const (
+	// ValidateOnlyHeader will make any route only validate, ex: X-Validate-Only: true
+	ValidateOnlyHeader = "X-Validate-Only"
+	// ValidateFieldsHeader limits a dry run's errors to the fields the
+	// user has touched, using the same keys as the error response,
+	// ex: X-Validate-Fields: Make, Artist
+	ValidateFieldsHeader = "X-Validate-Fields"
+)
+
+// DryRun will make the routes it's used on validate the request without
+// handling it - a 204 if it's valid, or the usual 400 if it's not.
+func DryRun() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("dryRun", true)
+		c.Next()
+	}
+}
+
+// mwDryRun will switch any route to a dry run when the request asks for
+// one with ValidateOnlyHeader.
+func mwDryRun() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if on, _ := strconv.ParseBool(c.GetHeader(ValidateOnlyHeader)); on {
+			c.Set("dryRun", true)
+		}
+		c.Next()
+	}
+}
+
+// touchedProblems will drop the problems for fields that aren't in the
+// comma separated list, keeping those nested under a listed field
+// (ex: Artist covers Artist[2]). An empty list keeps everything, and a
+// problem with the body as a whole is always kept - none of the fields
+// could be checked.
+func touchedProblems(problems []fieldProblem, list string, paths PathFormat) []fieldProblem {
+	fields := []string{}
+	for _, f := range strings.Split(list, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return problems
+	}
+
+	sep := paths.Separator
+	if sep == "" {
+		sep = DefaultPathFormat.Separator
+	}
+	ret := []fieldProblem{}
+	for _, fp := range problems {
+		if fp.Field == bodyField {
+			ret = append(ret, fp)
+			continue
+		}
+		for _, f := range fields {
+			if fp.Field == f || strings.HasPrefix(fp.Field, f+"[") ||
+				strings.HasPrefix(fp.Field, f+sep) || strings.HasPrefix(fp.Field, f+"/") {
+				ret = append(ret, fp)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// respondDryRun is used in place of a route's success callback on a dry run.
+func respondDryRun(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
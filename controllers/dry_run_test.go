@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestDryRun(t *testing.T) {
+	t.Run("DryRunTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:    "valid-model-by-path",
+				Path:    "/validate/CarExample",
+				ExpCode: 204,
+				Body:    models.CarExample{Make: "test make", Model: "test model"},
+			},
+			testCase{
+				Name:    "valid-model-by-header",
+				Path:    "/car",
+				ExpCode: 204,
+				Headers: map[string]string{ValidateOnlyHeader: "true"},
+				Body:    models.CarExample{Make: "test make", Model: "test model"},
+			},
+			testCase{
+				Name:        "invalid-model-by-path",
+				Path:        "/validate/CarExample",
+				ExpCode:     400,
+				Body:        models.CarExample{Make: "aa", Model: "test model"},
+				ExpFields:   []string{"Make"},
+				ExpMessages: []string{"Make must contain at least 3 characters"},
+			},
+			testCase{
+				Name:        "invalid-model-by-header",
+				Path:        "/car",
+				ExpCode:     400,
+				Headers:     map[string]string{ValidateOnlyHeader: "true"},
+				Body:        models.CarExample{Make: "aa", Model: "test model"},
+				ExpFields:   []string{"Make"},
+				ExpMessages: []string{"Make must contain at least 3 characters"},
+			},
+			testCase{
+				Name:    "only-untouched-fields-failed",
+				Path:    "/validate/CarExample",
+				ExpCode: 204,
+				Headers: map[string]string{ValidateFieldsHeader: "Make"},
+				Body:    models.CarExample{Make: "test make"},
+			},
+			testCase{
+				Name:        "body-problems-always-kept",
+				Path:        "/validate/CarExample",
+				ExpCode:     400,
+				Headers:     map[string]string{ValidateFieldsHeader: "Make"},
+				RawBody:     `{"Make": `,
+				ExpFields:   []string{"body"},
+				ExpMessages: []string{"Request body is not valid JSON at 1:10"},
+			},
+			testCase{
+				Name:        "touched-parent-covers-entries",
+				Path:        "/validate/AlbumExample",
+				ExpCode:     400,
+				Headers:     map[string]string{ValidateFieldsHeader: "Artist"},
+				Body:        models.AlbumExample{Artist: []string{"a"}},
+				ExpFields:   []string{"Artist[0]"},
+				ExpMessages: []string{"Artist entry 1 must contain at least 2 characters"},
+			},
+		}
+
+		runTests(t, tests, GetRouter())
+	})
+
+	t.Run("untouched fields are left out", func(t *testing.T) {
+		body, _ := json.Marshal(models.CarExample{Make: "aa"})
+		req := performRequest(GetRouter(), "POST", "/validate/CarExample", &body, map[string]string{
+			"Content-Type":       "application/json",
+			ValidateFieldsHeader: "Make",
+		})
+		assert.Equal(t, http.StatusBadRequest, req.Code)
+		errs := map[string]string{}
+		json.Unmarshal(req.Body.Bytes(), &errs)
+		assert.Equal(t, map[string]string{"Make": "Make must contain at least 3 characters"}, errs)
+	})
+}
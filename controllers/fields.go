@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"reflect"
+	"strings"
+)
+
+// fieldNameTags are checked in order for the name a client knows a field
+// by - the first one set wins, otherwise the Go field name is used.
+var fieldNameTags = []string{"json", "form", "xml", uriTag, headerTag}
+
+// labelTag overrides the display name used for a field in messages,
+// ex: `label:"Current password"`
+const labelTag = "label"
+
+// resolvePath will fill in the client facing key and the label for each
+// step of the path, using the struct tags on the given model type.
+func resolvePath(t reflect.Type, p fieldPath) fieldPath {
+	ret := make(fieldPath, len(p))
+	copy(ret, p)
+	for i, s := range ret {
+		t = indirect(t)
+		if s.IsIndex {
+			ret[i].IsKey = t.Kind() == reflect.Map
+			if t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+				t = t.Elem()
+			}
+			continue
+		}
+		if t.Kind() != reflect.Struct {
+			break
+		}
+		f, ok := t.FieldByName(s.Name)
+		if !ok {
+			break
+		}
+		ret[i].Key = fieldKey(f)
+		ret[i].Label = f.Tag.Get(labelTag)
+		ret[i].Secret = secretField(f)
+		t = f.Type
+	}
+	return ret
+}
+
+// siblingLabel will return the label tag of the named field in the same
+// struct as the field at the end of the path - this is used for the
+// other field in tags like eqfield=Password.
+func siblingLabel(t reflect.Type, p fieldPath, name string) string {
+	for _, s := range p[:len(p)-1] {
+		t = indirect(t)
+		if s.IsIndex {
+			if t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+				t = t.Elem()
+			}
+			continue
+		}
+		if t.Kind() != reflect.Struct {
+			return ""
+		}
+		f, ok := t.FieldByName(s.Name)
+		if !ok {
+			return ""
+		}
+		t = f.Type
+	}
+	t = indirect(t)
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+	f, ok := t.FieldByName(name)
+	if !ok {
+		return ""
+	}
+	return f.Tag.Get(labelTag)
+}
+
+// secretTags are the rules that only make sense on a secret, so a field
+// that uses one never has its value sent back in an error.
+var secretTags = map[string]bool{
+	"strongpassword":   true,
+	"minentropy":       true,
+	"charclasses":      true,
+	"notcontainsfield": true,
+	"notbreached":      true,
+}
+
+// secretField will report whether the field holds a secret - it uses one
+// of the secretTags, or it's named like a password.
+func secretField(f reflect.StructField) bool {
+	for _, rule := range parseRules(f.Tag.Get(bindingTag)) {
+		if secretTags[strings.SplitN(rule, "=", 2)[0]] {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(f.Name+" "+fieldKey(f)), "password")
+}
+
+// secret will report whether the value at the end of the path, or the
+// field it's part of, is a secret.
+func (p fieldPath) secret() bool {
+	for _, s := range p {
+		if s.Secret {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldKey will return the name a client uses for the given field.
+func fieldKey(f reflect.StructField) string {
+	for _, tag := range fieldNameTags {
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
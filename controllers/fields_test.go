@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type fieldsAddress struct {
+	Street string `json:"street" binding:"required"`
+}
+
+type fieldsExample struct {
+	Username        string        `json:"username" binding:"required"`
+	OldPassword     string        `json:"old_password" label:"Current password" binding:"required"`
+	Password        string        `json:"password" binding:"required,nefield=OldPassword"`
+	PasswordConfirm string        `form:"password_confirm" binding:"required,eqfield=Password"`
+	Address         fieldsAddress `json:"address"`
+}
+
+func fieldsRouter() *gin.Engine {
+	binding.Validator = modelValidator
+	r := gin.New()
+	r.Use(mwParseValidation(&routerConfig{paths: DefaultPathFormat}))
+	r.POST("/fields", func(c *gin.Context) {
+		var f fieldsExample
+		if err := c.Bind(&f); err != nil {
+			c.Set("controllerError", true)
+			return
+		}
+		c.Status(200)
+	})
+	return r
+}
+
+func TestFieldNames(t *testing.T) {
+	t.Run("FieldNameTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "json-names",
+				Path:        "/fields",
+				ExpCode:     400,
+				ExpFields:   []string{"username", "old_password", "password", "password_confirm", "address.street"},
+				ExpMessages: []string{"Username is required", "Current password is required", "Address street is required"},
+				Body:        map[string]interface{}{},
+			},
+			testCase{
+				Name:        "label-in-param",
+				Path:        "/fields",
+				ExpCode:     400,
+				ExpFields:   []string{"password"},
+				ExpMessages: []string{"Password must not be the same as Current password"},
+				Body: json.RawMessage(`{"username":"u","old_password":"same","password":"same",` +
+					`"PasswordConfirm":"same","address":{"street":"main"}}`),
+			},
+		}
+		runTests(t, tests, fieldsRouter())
+	})
+}
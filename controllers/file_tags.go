@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"  // so DecodeConfig knows gifs
+	_ "image/jpeg" // and jpegs
+	_ "image/png"  // and pngs
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+	fileListType   = reflect.TypeOf([]*multipart.FileHeader{})
+)
+
+// uploadedFile is what validator sees a multipart.FileHeader as. The
+// header is a struct, and validator looks inside structs instead of
+// running the field's tags on them.
+type uploadedFile [1]*multipart.FileHeader
+
+// sizeUnits are the suffixes maxsize understands, biggest first.
+var sizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+func init() {
+	v := modelValidator.Engine().(*validator.Validate)
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		fh := field.Interface().(multipart.FileHeader)
+		return uploadedFile{&fh}
+	}, multipart.FileHeader{})
+
+	tags := []struct {
+		tag      string
+		fn       validator.Func
+		template string
+	}{
+		{"maxsize", isMaxSize, "{field} must be no larger than {param}"},
+		{"mimetypes", hasMIMEType, "{field} must be one of these types: {param}"},
+		{"maxdimensions", hasMaxDimensions, "{field} must be no larger than {param} pixels"},
+		{"mindimensions", hasMinDimensions, "{field} must be at least {param} pixels"},
+		{"maxfiles", hasMaxFiles, "{field} must have no more than {param} {unit}"},
+	}
+	for _, t := range tags {
+		if err := RegisterValidation(t.tag, t.fn, t.template); err != nil {
+			panic(err)
+		}
+	}
+
+	paramChecks["maxsize"] = func(param string) error {
+		_, err := parseSize(param)
+		return err
+	}
+	paramChecks["maxdimensions"] = func(param string) error {
+		_, _, err := parseDimensions("maxdimensions", param)
+		return err
+	}
+	paramChecks["mindimensions"] = func(param string) error {
+		_, _, err := parseDimensions("mindimensions", param)
+		return err
+	}
+	paramChecks["maxfiles"] = func(param string) error {
+		_, err := parseMaxFiles(param)
+		return err
+	}
+}
+
+// fieldFile will return the field's value as an uploaded file, if it is one.
+func fieldFile(field reflect.Value) (*multipart.FileHeader, bool) {
+	f, ok := field.Interface().(uploadedFile)
+	return f[0], ok && f[0] != nil
+}
+
+// isMaxSize will check the file is no bigger than the size in param,
+// ex: maxsize=2MB
+func isMaxSize(fl validator.FieldLevel) bool {
+	fh, ok := fieldFile(fl.Field())
+	size, err := parseSize(fl.Param())
+	return ok && err == nil && fh.Size <= size
+}
+
+// hasMIMEType will check the file's content - not the type the client
+// said it was - is one of the space separated types in param, ex:
+// mimetypes=image/png image/jpeg or mimetypes=image/*
+func hasMIMEType(fl validator.FieldLevel) bool {
+	fh, ok := fieldFile(fl.Field())
+	if !ok {
+		return false
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	head := make([]byte, 512)
+	n, _ := f.Read(head)
+	sniffed := strings.SplitN(http.DetectContentType(head[:n]), ";", 2)[0]
+
+	for _, allowed := range strings.Fields(fl.Param()) {
+		if allowed == sniffed || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(sniffed, strings.TrimSuffix(allowed, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMaxDimensions will check the file is an image no wider or taller than
+// the size in param, ex: maxdimensions=1024x768
+func hasMaxDimensions(fl validator.FieldLevel) bool {
+	width, height, ok := imageSize(fl.Field())
+	maxWidth, maxHeight, err := parseDimensions("maxdimensions", fl.Param())
+	return ok && err == nil && width <= maxWidth && height <= maxHeight
+}
+
+// hasMinDimensions will check the file is an image at least as wide and
+// tall as the size in param, ex: mindimensions=64x64
+func hasMinDimensions(fl validator.FieldLevel) bool {
+	width, height, ok := imageSize(fl.Field())
+	minWidth, minHeight, err := parseDimensions("mindimensions", fl.Param())
+	return ok && err == nil && width >= minWidth && height >= minHeight
+}
+
+// hasMaxFiles will check no more files than param were uploaded,
+// ex: maxfiles=3
+func hasMaxFiles(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		return false
+	}
+	n, err := parseMaxFiles(fl.Param())
+	return err == nil && field.Len() <= n
+}
+
+// imageSize will return the width and height of the uploaded image,
+// without decoding the whole thing.
+func imageSize(field reflect.Value) (int, int, bool) {
+	fh, ok := fieldFile(field)
+	if !ok {
+		return 0, 0, false
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return 0, 0, false
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, false
+	}
+	return cfg.Width, cfg.Height, true
+}
+
+// parseSize will return the number of bytes in a size like 512, 500KB or
+// 1.5MB - a KB is 1024 bytes.
+func parseSize(param string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(param))
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), 64)
+			if err != nil || n < 0 {
+				break
+			}
+			return int64(n * float64(u.size)), nil
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && n >= 0 {
+		return n, nil
+	}
+	return 0, fmt.Errorf("maxsize: bad size %q", param)
+}
+
+// parseDimensions will return the width and height in a param like 1024x768.
+func parseDimensions(tag string, param string) (int, int, error) {
+	parts := strings.SplitN(strings.ToLower(param), "x", 2)
+	if len(parts) == 2 {
+		width, err1 := strconv.Atoi(parts[0])
+		height, err2 := strconv.Atoi(parts[1])
+		if err1 == nil && err2 == nil {
+			return width, height, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("%s: bad param %q", tag, param)
+}
+
+// parseMaxFiles will return the number of files in a maxfiles param.
+func parseMaxFiles(param string) (int, error) {
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("maxfiles: bad param %q", param)
+	}
+	return n, nil
+}
+
+// size will write a number of bytes in the biggest unit it fits,
+// ex: 1572864 -> 1.5 MB
+func (l *Localizer) size(n int64) string {
+	for _, u := range sizeUnits[:len(sizeUnits)-1] {
+		if n >= u.size {
+			size := strconv.FormatFloat(float64(n)/float64(u.size), 'f', 1, 64)
+			return strings.TrimSuffix(size, ".0") + " " + u.suffix
+		}
+	}
+	return l.count(int(n), "byte")
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+// pngOf will return a blank png of the given size.
+func pngOf(width int, height int) []byte {
+	buf := &bytes.Buffer{}
+	png.Encode(buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+func TestFileTags(t *testing.T) {
+	type file struct {
+		field   string
+		name    string
+		content []byte
+	}
+	upload := func(t *testing.T, files ...file) (int, map[string]string) {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+		w.WriteField("display_name", "Mike")
+		for _, f := range files {
+			part, _ := w.CreateFormFile(f.field, f.name)
+			part.Write(f.content)
+		}
+		w.Close()
+		bs := body.Bytes()
+		req := performRequest(GetRouter(), "POST", "/profile", &bs, map[string]string{"Content-Type": w.FormDataContentType()})
+		errs := map[string]string{}
+		json.Unmarshal(req.Body.Bytes(), &errs)
+		return req.Code, errs
+	}
+	pdf := []byte("%PDF-1.4\n%âãÏÓ\n")
+
+	t.Run("valid upload", func(t *testing.T) {
+		code, errs := upload(t, file{"avatar", "me.png", pngOf(128, 128)}, file{"attachments", "cv.pdf", pdf})
+		assert.Equal(t, http.StatusOK, code, errs)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		code, errs := upload(t)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, map[string]string{"avatar": "Avatar is required"}, errs)
+	})
+
+	t.Run("too big", func(t *testing.T) {
+		big := append(pngOf(128, 128), make([]byte, 1<<20)...)
+		_, errs := upload(t, file{"avatar", "me.png", big})
+		assert.Equal(t, map[string]string{"avatar": "Avatar must be no larger than 1 MB"}, errs)
+	})
+
+	t.Run("type is sniffed, not taken from the name", func(t *testing.T) {
+		_, errs := upload(t, file{"avatar", "me.png", pdf})
+		assert.Equal(t, "Avatar must be one of these types: image/png, image/jpeg", errs["avatar"])
+	})
+
+	t.Run("dimensions", func(t *testing.T) {
+		_, errs := upload(t, file{"avatar", "me.png", pngOf(1024, 128)})
+		assert.Equal(t, map[string]string{"avatar": "Avatar must be no larger than 512 × 512 pixels"}, errs)
+
+		_, errs = upload(t, file{"avatar", "me.png", pngOf(32, 32)})
+		assert.Equal(t, map[string]string{"avatar": "Avatar must be at least 64 × 64 pixels"}, errs)
+	})
+
+	t.Run("each file in a list", func(t *testing.T) {
+		_, errs := upload(t, file{"avatar", "me.png", pngOf(128, 128)}, file{"attachments", "cv.pdf", pdf}, file{"attachments", "notes.txt", []byte("hello")})
+		assert.Equal(t, map[string]string{"attachments[1]": "Attachments entry 2 must be one of these types: application/pdf, image/*"}, errs)
+	})
+
+	t.Run("too many files", func(t *testing.T) {
+		files := []file{{"avatar", "me.png", pngOf(128, 128)}}
+		for i := 0; i < 4; i++ {
+			files = append(files, file{"attachments", "cv.pdf", pdf})
+		}
+		_, errs := upload(t, files...)
+		assert.Equal(t, map[string]string{"attachments": "Attachments must have no more than 3 files"}, errs)
+	})
+}
+
+func TestSize(t *testing.T) {
+	l := Messages.localizer()
+	for param, want := range map[string]string{
+		"1":     "1 byte",
+		"512":   "512 bytes",
+		"500KB": "500 KB",
+		"1.5MB": "1.5 MB",
+		"2mb":   "2 MB",
+		"1GB":   "1 GB",
+	} {
+		n, err := parseSize(param)
+		assert.Equal(t, nil, err, param)
+		assert.Equal(t, want, l.size(n), param)
+	}
+	assert.Equal(t, "512 Bytes", NegotiateLocale("de").size(512))
+	assert.Equal(t, "512バイト", NegotiateLocale("ja").size(512))
+}
+
+func TestBadParams(t *testing.T) {
+	type badUpload struct {
+		Avatar      multipart.FileHeader    `binding:"maxsize=2XB,maxdimensions=1024"`
+		Thumbnail   multipart.FileHeader    `binding:"mindimensions=64x64"`
+		Attachments []*multipart.FileHeader `binding:"maxfiles=three"`
+	}
+	assert.Equal(t, []string{
+		`maxdimensions: bad param "1024"`,
+		`maxfiles: bad param "three"`,
+		`maxsize: bad size "2XB"`,
+	}, BadParams(badUpload{}))
+	assert.Equal(t, []string{}, BadParams(registeredModels()...))
+}
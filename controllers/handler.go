@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// Source is where a model's values are bound from.
+type Source int
+
+const (
+	// FromBody picks the binding from the Content-Type, the same as c.Bind
+	FromBody Source = iota
+	// FromJSON binds the body as JSON regardless of the Content-Type
+	FromJSON
+	// FromForm binds urlencoded and multipart form values
+	FromForm
+	// FromQuery binds the query string, using the form tag for names
+	FromQuery
+	// FromURI binds the route's path params, using the uri tag for names
+	FromURI
+	// FromRequest binds each field from wherever its tag says - uri for a
+	// path param, header for a header, form for the query string, and the
+	// JSON body for the rest. Its error keys say where, ex: query.limit
+	FromRequest
+)
+
+// uriTag names the path param a field is bound from, ex: `uri:"id"`
+const uriTag = "uri"
+
+var (
+	boundModelsMu sync.Mutex
+	boundModels   = []interface{}{}
+)
+
+// BindHandler will return a handler that binds a T from the given source,
+// validates it, runs any async checks, and hands it to onSuccess. If
+// binding or validation fails the error is left for mwParseValidation to
+// write out. A PATCH binds the JSON body and only validates the fields
+// that were sent.
+//
+// ex: r.POST("/car", BindHandler(FromBody, respondOK[models.CarExample]))
+func BindHandler[T any](source Source, onSuccess func(c *gin.Context, v *T)) gin.HandlerFunc {
+	var model T
+	registerModel(model)
+
+	return func(c *gin.Context) {
+		var v T
+		var err error
+		absent := map[string]bool{}
+		if c.Request.Method == http.MethodPatch {
+			absent, err = bindPartial(c, &v)
+		} else {
+			err = bindFrom(c, source, &v)
+		}
+		if failed, ok := failedFields(err); ok {
+			for ns := range absent {
+				// fields that weren't sent aren't being changed
+				failed[ns] = true
+			}
+			if errs := runAsyncChecks(c.Request.Context(), &v, failed); errs != nil {
+				err = recordBindError(c, errs)
+			}
+		}
+		if err != nil {
+			c.Set("controllerError", true)
+			return
+		}
+		if c.GetBool("dryRun") {
+			respondDryRun(c)
+			return
+		}
+		onSuccess(c, &v)
+	}
+}
+
+// respondOK is the success callback for endpoints that only validate.
+func respondOK[T any](c *gin.Context, v *T) {
+	c.Status(http.StatusOK)
+}
+
+// registerModel will set up the struct level rules for a model a handler
+// binds, and remember it so GetRouter can check its messages.
+func registerModel(model interface{}) {
+	registerStructHooks(reflect.TypeOf(model))
+	boundModelsMu.Lock()
+	boundModels = append(boundModels, model)
+	boundModelsMu.Unlock()
+}
+
+// registeredModels will return a zero value of every model a BindHandler
+// has been built for.
+func registeredModels() []interface{} {
+	boundModelsMu.Lock()
+	defer boundModelsMu.Unlock()
+	ret := make([]interface{}, len(boundModels))
+	copy(ret, boundModels)
+	return ret
+}
+
+// failedFields will return the namespaces of the fields that failed
+// validation, or false if the model couldn't be bound at all.
+func failedFields(err error) (map[string]bool, bool) {
+	failed := map[string]bool{}
+	switch errs := err.(type) {
+	case nil:
+	case *modelErrors:
+		for _, e := range errs.Errors {
+			failed[newFieldError(e).Namespace] = true
+		}
+	default:
+		return nil, false
+	}
+	return failed, true
+}
+
+// bindFrom will bind and validate obj from the given source, recording
+// any error on the context.
+func bindFrom(c *gin.Context, source Source, obj interface{}) error {
+	switch source {
+	case FromJSON:
+		return recordBindError(c, c.ShouldBindWith(obj, jsonBody(c)))
+	case FromForm:
+		var b binding.Binding = binding.FormPost
+		if c.ContentType() == gin.MIMEMultipartPOSTForm {
+			b = binding.FormMultipart
+		}
+		parseForm(c)
+		if err := checkFormTypes(obj, c.Request.PostForm); err != nil {
+			return recordBindError(c, err)
+		}
+		return recordBindError(c, c.ShouldBindWith(obj, b))
+	case FromQuery:
+		if err := checkFormTypes(obj, c.Request.URL.Query()); err != nil {
+			return recordBindError(c, err)
+		}
+		return recordBindError(c, c.ShouldBindWith(obj, binding.Query))
+	case FromURI:
+		err := mapURI(obj, c.Params)
+		if err == nil && binding.Validator != nil {
+			err = binding.Validator.ValidateStruct(obj)
+		}
+		return recordBindError(c, err)
+	case FromRequest:
+		c.Set("requestModel", reflect.TypeOf(obj).Elem())
+		return recordBindError(c, bindRequest(c, obj))
+	}
+	b := binding.Default(c.Request.Method, c.ContentType())
+	switch b {
+	case binding.JSON:
+		b = jsonBody(c)
+	case binding.Form, binding.FormMultipart:
+		parseForm(c)
+		if err := checkFormTypes(obj, c.Request.Form); err != nil {
+			return recordBindError(c, err)
+		}
+	}
+	return recordBindError(c, c.ShouldBindWith(obj, b))
+}
+
+// recordBindError will leave err for mwParseValidation the way c.Bind
+// does, but without writing the status yet - a dry run can still turn
+// it into a 204.
+func recordBindError(c *gin.Context, err error) error {
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		c.Abort()
+	}
+	return err
+}
+
+// mapURI will set the fields of obj from the route's path params.
+func mapURI(obj interface{}, params gin.Params) error {
+	val := reflect.ValueOf(obj).Elem()
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		name := typ.Field(i).Tag.Get(uriTag)
+		if name == "" || !val.Field(i).CanSet() {
+			continue
+		}
+		value, ok := params.Get(name)
+		if !ok {
+			continue
+		}
+		if err := setField(val.Field(i), value); err != nil {
+			return &typeError{Field: typ.Field(i).Name, Type: typ.Field(i).Type, Model: typ}
+		}
+	}
+	return nil
+}
+
+// parseForm will parse the request's form so it can be checked before it's
+// bound. A bad form is left for the binding to report.
+func parseForm(c *gin.Context) {
+	if c.ContentType() == gin.MIMEMultipartPOSTForm {
+		_, _ = c.MultipartForm()
+		return
+	}
+	_ = c.Request.ParseForm()
+}
+
+// checkFormTypes will make sure the values sent for obj's number and bool
+// fields can be converted before gin binds them, since gin's error doesn't
+// say which field it was. An empty value is left for gin, which takes it
+// as zero.
+func checkFormTypes(obj interface{}, values url.Values) error {
+	typ := reflect.TypeOf(obj).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		key := strings.Split(f.Tag.Get("form"), ",")[0]
+		if f.PkgPath != "" || key == "-" || !scalarKind(f.Type) {
+			continue
+		}
+		if key == "" {
+			key = f.Name
+		}
+		sent := []string{}
+		for _, v := range values[key] {
+			if v != "" {
+				sent = append(sent, v)
+			}
+		}
+		if err := setValues(reflect.New(f.Type).Elem(), sent); err != nil {
+			return &typeError{Field: f.Name, Type: f.Type, Model: typ}
+		}
+	}
+	return nil
+}
+
+// scalarKind will tell if setField can convert to t, or the type of its
+// entries for a slice, and fail doing it - a number or a bool.
+func scalarKind(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// setField will convert the string to the field's kind and set it.
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.Ptr:
+		// a pointer is only set when the value was sent, so zero can
+		// be told apart from missing
+		v := reflect.New(field.Type().Elem())
+		if err := setField(v.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(v)
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	default:
+		return strconv.ErrSyntax
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type handlerCarExample struct {
+	Make  string `form:"make" binding:"required,gte=3"`
+	Model string `form:"model"`
+	Year  int    `form:"year" json:",omitempty"`
+	Used  bool   `form:"used" json:",omitempty"`
+}
+
+type handlerIDExample struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type handlerOwnerExample struct {
+	OwnerNumber int `uri:"owner" label:"Owner" binding:"required"`
+}
+
+func handlerRouter() *gin.Engine {
+	binding.Validator = modelValidator
+	r := gin.New()
+	r.Use(mwParseValidation(&routerConfig{paths: DefaultPathFormat}))
+	writeCar := func(c *gin.Context, v *handlerCarExample) {
+		c.JSON(http.StatusOK, v)
+	}
+	r.GET("/cars", BindHandler(FromQuery, writeCar))
+	r.POST("/cars", BindHandler(FromForm, writeCar))
+	r.GET("/cars/:id", BindHandler(FromURI, func(c *gin.Context, v *handlerIDExample) {
+		c.JSON(http.StatusOK, v)
+	}))
+	r.GET("/owners/:owner", BindHandler(FromURI, func(c *gin.Context, v *handlerOwnerExample) {
+		c.JSON(http.StatusOK, v)
+	}))
+	return r
+}
+
+func TestBindHandler(t *testing.T) {
+	r := handlerRouter()
+	t.Run("Query", func(t *testing.T) {
+		req := performRequest(r, "GET", "/cars?make=ford&model=focus", nil, nil)
+		assert.Equal(t, 200, req.Code, req.Body)
+		assert.Equal(t, `{"Make":"ford","Model":"focus"}`, req.Body.String())
+
+		req = performRequest(r, "GET", "/cars?make=fo", nil, nil)
+		assertCodeAndMessages(t, testCase{
+			ExpCode:     400,
+			ExpFields:   []string{"make"},
+			ExpMessages: []string{"Make must contain at least 3 characters"},
+		}, req)
+
+		req = performRequest(r, "GET", "/cars?make=ford&year=abc", nil, nil)
+		assertCodeAndMessages(t, testCase{
+			ExpCode:     400,
+			ExpFields:   []string{"year"},
+			ExpMessages: []string{"Year must be of type number"},
+		}, req)
+
+		req = performRequest(r, "GET", "/cars?make=ford&year=", nil, nil)
+		assert.Equal(t, 200, req.Code, req.Body)
+	})
+	t.Run("Form", func(t *testing.T) {
+		body := []byte("make=ford")
+		headers := map[string]string{"Content-Type": "application/x-www-form-urlencoded"}
+		req := performRequest(r, "POST", "/cars", &body, headers)
+		assert.Equal(t, 200, req.Code, req.Body)
+		assert.Equal(t, `{"Make":"ford","Model":""}`, req.Body.String())
+
+		body = []byte("make=ford&used=maybe")
+		req = performRequest(r, "POST", "/cars", &body, headers)
+		assertCodeAndMessages(t, testCase{
+			ExpCode:     400,
+			ExpFields:   []string{"used"},
+			ExpMessages: []string{"Used must be of type boolean"},
+		}, req)
+	})
+	t.Run("URI", func(t *testing.T) {
+		req := performRequest(r, "GET", "/cars/12", nil, nil)
+		assert.Equal(t, 200, req.Code, req.Body)
+		assert.Equal(t, `{"ID":12}`, req.Body.String())
+
+		req = performRequest(r, "GET", "/cars/0", nil, nil)
+		assertCodeAndMessages(t, testCase{
+			ExpCode:     400,
+			ExpFields:   []string{"id"},
+			ExpMessages: []string{"ID is required"},
+		}, req)
+
+		req = performRequest(r, "GET", "/cars/abc", nil, nil)
+		assertCodeAndMessages(t, testCase{
+			ExpCode:     400,
+			ExpFields:   []string{"id"},
+			ExpMessages: []string{"ID must be of type number"},
+		}, req)
+
+		req = performRequest(r, "GET", "/owners/abc", nil, nil)
+		assertCodeAndMessages(t, testCase{
+			ExpCode:     400,
+			ExpFields:   []string{"owner"},
+			ExpMessages: []string{"Owner must be of type number"},
+		}, req)
+	})
+}
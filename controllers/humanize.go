@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The character classes Humanize splits words on.
+const (
+	classSeparator = iota
+	classLower
+	classUpper
+	classDigit
+	classCaseless
+	classOther
+)
+
+var (
+	acronymsMu sync.RWMutex
+	acronyms   = map[string]string{}
+)
+
+func init() {
+	RegisterAcronyms("ID", "URL", "URI", "UUID", "API", "JSON", "XML", "HTML", "HTTP", "HTTPS", "IP", "CSV", "SQL")
+}
+
+// RegisterAcronyms will add words that Humanize keeps in the given case
+// instead of lowercasing, ex: RegisterAcronyms("SKU", "iOS")
+func RegisterAcronyms(words ...string) {
+	acronymsMu.Lock()
+	defer acronymsMu.Unlock()
+	for _, w := range words {
+		acronyms[strings.ToUpper(w)] = w
+	}
+}
+
+// acronym will return how the word is written if it's a known acronym.
+func acronym(word string) (string, bool) {
+	acronymsMu.RLock()
+	defer acronymsMu.RUnlock()
+	a, ok := acronyms[strings.ToUpper(word)]
+	return a, ok
+}
+
+// Humanize will turn a field name into words, the way Split does, except:
+// - known acronyms keep their case (ex: VisitorID -> Visitor ID)
+// - underscores, dashes, dots and spaces separate words (ex: visitor_id)
+// - letters without case (ex: 名前) are kept as they are
+func Humanize(name string) string {
+	return humanize(name, true)
+}
+
+// humanize will split name into words, capitalizing the first one if
+// first is set, and lowercasing the rest unless they're acronyms.
+func humanize(name string, first bool) string {
+	return humanizeWith(name, first, acronym)
+}
+
+// humanizeWith is humanize with the acronym dictionary passed in.
+func humanizeWith(name string, first bool, acronym func(string) (string, bool)) string {
+	// don't split invalid utf8
+	if !utf8.ValidString(name) {
+		return name
+	}
+	words := humanWords(name, acronym)
+	for i, w := range words {
+		if a, ok := acronym(w); ok {
+			words[i] = a
+			continue
+		}
+		if a, ok := acronym(strings.TrimSuffix(w, "s")); ok && strings.HasSuffix(w, "s") {
+			// plural acronyms, ex: UserIDs -> User IDs
+			words[i] = a + "s"
+			continue
+		}
+		if i == 0 && first {
+			// same as Split - the rest of the first word is left alone
+			words[i] = UcFirst(w)
+			continue
+		}
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// humanWords will split name into words on separators, changes of case
+// and changes between letters, digits and everything else.
+func humanWords(name string, acronym func(string) (string, bool)) []string {
+	runs := [][]rune{}
+	last := classSeparator
+	for _, r := range name {
+		class := runeClass(r)
+		switch {
+		case unicode.IsMark(r) && len(runs) > 0 && last != classSeparator:
+			// combining marks belong to the letter before them
+			runs[len(runs)-1] = append(runs[len(runs)-1], r)
+			continue
+		case class == classSeparator:
+		case class == last:
+			runs[len(runs)-1] = append(runs[len(runs)-1], r)
+		default:
+			runs = append(runs, []rune{r})
+		}
+		last = class
+	}
+
+	// an uppercase letter followed by lowercase ones starts a new word,
+	// ex: HTTPServer -> HTTP Server, unless the uppercase ones are an
+	// acronym on their own and the ones before the last aren't
+	for i := 0; i < len(runs)-1; i++ {
+		if len(runs[i]) == 0 || len(runs[i+1]) == 0 ||
+			runeClass(runs[i][0]) != classUpper || runeClass(runs[i+1][0]) != classLower {
+			continue
+		}
+		_, prefix := acronym(string(runs[i][:len(runs[i])-1]))
+		if _, ok := acronym(string(runs[i])); ok && !prefix && len(runs[i]) > 1 {
+			// the acronym is a whole word, ex: APIkey -> API key
+			if string(runs[i+1]) == "s" {
+				runs[i] = append(runs[i], runs[i+1]...)
+				runs[i+1] = nil
+			}
+			continue
+		}
+		runs[i+1] = append([]rune{runs[i][len(runs[i])-1]}, runs[i+1]...)
+		runs[i] = runs[i][:len(runs[i])-1]
+	}
+
+	words := []string{}
+	for _, run := range runs {
+		if len(run) > 0 {
+			words = append(words, string(run))
+		}
+	}
+	return words
+}
+
+func runeClass(r rune) int {
+	switch {
+	case r == '_' || r == '-' || r == '.' || unicode.IsSpace(r):
+		return classSeparator
+	case unicode.IsLower(r):
+		return classLower
+	case unicode.IsUpper(r) || unicode.IsTitle(r):
+		return classUpper
+	case unicode.IsDigit(r):
+		return classDigit
+	case unicode.IsLetter(r) || unicode.IsMark(r):
+		return classCaseless
+	}
+	return classOther
+}
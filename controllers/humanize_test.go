@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestHumanize(t *testing.T) {
+	RegisterAcronyms("iOS")
+
+	tests := []struct {
+		Name     string
+		Expected string
+	}{
+		{"VisitorID", "Visitor ID"},
+		{"UserID", "User ID"},
+		{"UserIDs", "User IDs"},
+		{"WebsiteURL", "Website URL"},
+		{"HTTPServer", "HTTP server"},
+		{"APIkey", "API key"},
+		{"APIKey", "API key"},
+		{"visitor_id", "Visitor ID"},
+		{"callback-url", "Callback URL"},
+		{"address.line_2", "Address line 2"},
+		{"OldPassword", "Old password"},
+		{"Address2", "Address 2"},
+		{"iosVersion", "iOS version"},
+		{"名前", "名前"},
+		{"名前ID", "名前 ID"},
+		{"ÉtéDate", "Été date"},
+		{"ÜberName", "Über name"},
+		{"ΌνομαΧρήστη", "Όνομα χρήστη"},
+		{"CaféName", "Café name"},
+		{"", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, Humanize(tc.Name))
+		})
+	}
+
+	t.Run("NotFirst", func(t *testing.T) {
+		assert.Equal(t, "street name", humanize("StreetName", false))
+		assert.Equal(t, "URL", humanize("URL", false))
+	})
+}
+
+func noAcronyms(string) (string, bool) {
+	return "", false
+}
+
+// FuzzHumanize checks Humanize against Split - without any acronyms, and
+// for names made of cased letters and digits, they should agree exactly.
+func FuzzHumanize(f *testing.F) {
+	for _, seed := range []string{"VisitorID", "OldPassword", "HTTPServer", "Address2", "ÜberName", "名前", "snake_case", "", "aB1cD"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		got := humanizeWith(name, true, noAcronyms)
+		if !utf8.ValidString(name) {
+			if got != name {
+				t.Fatalf("invalid utf8 should be left alone: %q -> %q", name, got)
+			}
+			return
+		}
+		if !utf8.ValidString(got) {
+			t.Fatalf("%q -> invalid utf8 %q", name, got)
+		}
+
+		// letters without case aren't changed or dropped
+		caseless := func(s string) string {
+			return strings.Map(func(r rune) rune {
+				if runeClass(r) == classCaseless && !unicode.IsMark(r) {
+					return r
+				}
+				return -1
+			}, s)
+		}
+		if caseless(got) != caseless(name) {
+			t.Fatalf("%q -> %q changed letters without case", name, got)
+		}
+
+		for _, r := range name {
+			if !unicode.IsLower(r) && !unicode.IsUpper(r) && !unicode.IsDigit(r) {
+				return
+			}
+		}
+		if split := Split(name); got != split {
+			t.Fatalf("%q: Humanize %q, Split %q", name, got, split)
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SchemasPath is where GetRouter serves the JSON Schema for each model,
+// ex: GET /schemas/CarExample
+const SchemasPath = "/schemas/:model"
+
+// JSONSchemaDialect is the draft the schemas are written in.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// indexPlaceholder stands in for the entry in the messages for the items
+// of a slice or map - its position counting from 1, or its map key - ex:
+// Artist entry {index} must be...
+const indexPlaceholder = "{index}"
+
+// JSONSchema is a model's rules written as JSON Schema, with the message
+// we'd send back for each rule in x-messages, keyed by the rule, ex:
+// "x-messages": {"required": "Make is required"}. Async rules and the
+// rules a model's Rules lists are in x-messages as well.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+	MinProperties        *int                   `json:"minProperties,omitempty"`
+	MaxProperties        *int                   `json:"maxProperties,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64               `json:"exclusiveMaximum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	UniqueItems          bool                   `json:"uniqueItems,omitempty"`
+	Messages             map[string]string      `json:"x-messages,omitempty"`
+}
+
+// JSONSchemaFor will describe the given model as JSON Schema, with the
+// default (English) messages.
+func JSONSchemaFor(model interface{}) *JSONSchema {
+	return Messages.localizer().JSONSchema(model)
+}
+
+// JSONSchema will describe the given model as JSON Schema, with the
+// messages in this localizer's language. Rules on the entries of a slice
+// or map use {index} where the entry's position (from 1) or key goes.
+func (l *Localizer) JSONSchema(model interface{}) *JSONSchema {
+	t := indirect(reflect.TypeOf(model))
+	s := toJSONSchema(SchemaFor(model))
+	s.Schema = JSONSchemaDialect
+	s.Title = t.Name()
+	l.addMessages(s, t, t, "")
+	return s
+}
+
+// toJSONSchema will convert an OpenAPI schema - the only real difference
+// is exclusiveMinimum and exclusiveMaximum are numbers instead of flags.
+func toJSONSchema(s *Schema) *JSONSchema {
+	if s == nil {
+		return nil
+	}
+	js := &JSONSchema{
+		Type:                 s.Type,
+		Format:               s.Format,
+		Required:             s.Required,
+		Items:                toJSONSchema(s.Items),
+		AdditionalProperties: toJSONSchema(s.AdditionalProperties),
+		Enum:                 s.Enum,
+		MinLength:            s.MinLength,
+		MaxLength:            s.MaxLength,
+		MinItems:             s.MinItems,
+		MaxItems:             s.MaxItems,
+		MinProperties:        s.MinProperties,
+		MaxProperties:        s.MaxProperties,
+		Minimum:              s.Minimum,
+		Maximum:              s.Maximum,
+		Pattern:              s.Pattern,
+		UniqueItems:          s.UniqueItems,
+	}
+	if s.ExclusiveMinimum {
+		js.ExclusiveMinimum, js.Minimum = s.Minimum, nil
+	}
+	if s.ExclusiveMaximum {
+		js.ExclusiveMaximum, js.Maximum = s.Maximum, nil
+	}
+	if s.Properties != nil {
+		js.Properties = map[string]*JSONSchema{}
+		for name, prop := range s.Properties {
+			js.Properties[name] = toJSONSchema(prop)
+		}
+	}
+	return js
+}
+
+// addMessages will fill in x-messages for each field of the struct t,
+// which is found at ns in the model.
+func (l *Localizer) addMessages(s *JSONSchema, model reflect.Type, t reflect.Type, ns string) {
+	t = indirect(t)
+	if t.Kind() != reflect.Struct || t == timeType {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		prop := s.Properties[fieldKey(f)]
+		if prop == nil || f.PkgPath != "" {
+			continue
+		}
+		fieldNS := f.Name
+		if ns != "" {
+			fieldNS = ns + "." + f.Name
+		}
+		l.addFieldMessages(prop, model, f.Type, fieldNS, f.Tag.Get(bindingTag))
+		if rules := f.Tag.Get(asyncTag); rules != "" {
+			for _, rule := range strings.Split(rules, ",") {
+				l.addRuleMessage(prop, model, f.Type, fieldNS, rule)
+			}
+		}
+	}
+	l.addStructRuleMessages(s, model, t, ns)
+}
+
+// addStructRuleMessages will add the message for each rule t's Rules says
+// its Validate can break, to every field the rule blames.
+func (l *Localizer) addStructRuleMessages(s *JSONSchema, model reflect.Type, t reflect.Type, ns string) {
+	rd, ok := reflect.Zero(t).Interface().(RuleDescriber)
+	if !ok {
+		rd, ok = reflect.New(t).Interface().(RuleDescriber)
+	}
+	if !ok {
+		return
+	}
+	for _, re := range rd.Rules() {
+		rule := re.Tag
+		if re.Param != "" {
+			rule += "=" + re.Param
+		}
+		for _, name := range re.Fields {
+			f, ok := t.FieldByName(name)
+			if !ok || s.Properties[fieldKey(f)] == nil {
+				continue
+			}
+			fieldNS := f.Name
+			if ns != "" {
+				fieldNS = ns + "." + f.Name
+			}
+			l.addRuleMessage(s.Properties[fieldKey(f)], model, f.Type, fieldNS, rule)
+		}
+	}
+}
+
+// addRuleMessage will add the message for a single rule, ex: available=usernames
+func (l *Localizer) addRuleMessage(s *JSONSchema, model reflect.Type, t reflect.Type, ns string, rule string) {
+	parts := strings.SplitN(rule, "=", 2)
+	tag, param := parts[0], ""
+	if len(parts) == 2 {
+		param = parts[1]
+	}
+	if s.Messages == nil {
+		s.Messages = map[string]string{}
+	}
+	s.Messages[rule] = l.ruleMessage(model, t, ns, tag, param)
+}
+
+// addFieldMessages will render the message for each rule in a field's
+// binding tag, following dive into the items the same way SchemaFor does.
+func (l *Localizer) addFieldMessages(s *JSONSchema, model reflect.Type, t reflect.Type, ns string, binding string) {
+	inKeys := false
+	for _, rule := range strings.Split(binding, ",") {
+		if inKeys {
+			inKeys = rule != "endkeys"
+			continue
+		}
+		tag, param := rule, ""
+		if !strings.Contains(rule, "|") {
+			// OR'd rules fail with the whole rule as the tag
+			parts := strings.SplitN(rule, "=", 2)
+			tag = parts[0]
+			if len(parts) == 2 {
+				param = parts[1]
+			}
+		}
+
+		switch tag {
+		case "", "-", "omitempty", "structonly", "nostructlevel":
+			continue
+		case "keys":
+			inKeys = true
+			continue
+		case "dive":
+			next := s.Items
+			if next == nil {
+				next = s.AdditionalProperties
+			}
+			t = indirect(t)
+			if next == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map) {
+				return
+			}
+			s, t = next, t.Elem()
+			ns += "[" + indexPlaceholder + "]"
+			continue
+		}
+
+		if s.Messages == nil {
+			s.Messages = map[string]string{}
+		}
+		s.Messages[rule] = l.ruleMessage(model, t, ns, tag, param)
+	}
+	l.addMessages(s, model, t, ns)
+}
+
+// ruleMessage will render the message a rule would fail with, the same
+// way fieldProblems does for a real error.
+func (l *Localizer) ruleMessage(model reflect.Type, t reflect.Type, ns string, tag string, param string) string {
+	t = indirect(t)
+	fe := &fieldError{Namespace: ns, Tag: tag, Param: param, Kind: t.Kind(), Type: t}
+	path := resolvePath(model, parsePath(ns))
+	labels := map[string]string{}
+	fields, _ := paramFields(tag, param)
+	for _, f := range fields {
+		labels[f] = siblingLabel(model, path, f)
+	}
+	return l.render(fe, path, labels)
+}
+
+// serveSchemas will write out the JSON Schema for the model a route
+// binds, looked up by its type name, in the language the client asked for.
+func serveSchemas(routes []route) gin.HandlerFunc {
+	models := map[string]interface{}{}
+	for _, rt := range routes {
+		models[rt.modelName()] = rt.Model
+	}
+	return func(c *gin.Context) {
+		model, ok := models[c.Param("model")]
+		if !ok {
+			c.JSON(http.StatusNotFound, map[string]string{"msg": "no schema for " + c.Param("model")})
+			return
+		}
+		loc := NegotiateLocale(c.GetHeader("Accept-Language"))
+		c.Header("Content-Language", loc.Locale)
+		c.JSON(http.StatusOK, loc.JSONSchema(model))
+	}
+}
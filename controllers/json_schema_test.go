@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestJSONSchemaFor(t *testing.T) {
+	t.Run("messages match what the server sends back", func(t *testing.T) {
+		s := JSONSchemaFor(models.CarExample{})
+		assert.Equal(t, JSONSchemaDialect, s.Schema)
+		assert.Equal(t, "CarExample", s.Title)
+
+		body, _ := json.Marshal(models.CarExample{Make: "VW", Model: "Golf"})
+		req := performRequest(GetRouter(), "POST", "/car", &body, map[string]string{"Content-Type": "application/json"})
+		resp := map[string]string{}
+		json.Unmarshal(req.Body.Bytes(), &resp)
+		assert.Equal(t, resp["Make"], s.Properties["Make"].Messages["gte=3"])
+	})
+
+	t.Run("dive rules use a placeholder for the index", func(t *testing.T) {
+		items := JSONSchemaFor(models.AlbumExample{}).Properties["Artist"].Items
+		assert.Equal(t, "Artist entry {index} must contain at least 2 characters", items.Messages["gte=2"])
+	})
+
+	t.Run("exclusive bounds are numbers", func(t *testing.T) {
+		type example struct {
+			Count int `binding:"gt=0,lt=10"`
+		}
+		count := JSONSchemaFor(example{}).Properties["Count"]
+		assert.Equal(t, 0.0, *count.ExclusiveMinimum)
+		assert.Equal(t, 10.0, *count.ExclusiveMaximum)
+		assert.T(t, count.Minimum == nil && count.Maximum == nil)
+	})
+}
+
+func TestSchemasRoute(t *testing.T) {
+	t.Run("known model", func(t *testing.T) {
+		req := performRequest(GetRouter(), "GET", "/schemas/LeadSourceExample", nil, nil)
+		assert.Equal(t, http.StatusOK, req.Code)
+		s := JSONSchema{}
+		if err := json.Unmarshal(req.Body.Bytes(), &s); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "Source detail is required when Source is other", s.Properties["SourceDetail"].Messages["required_if=Source other"])
+	})
+
+	t.Run("messages follow Accept-Language", func(t *testing.T) {
+		req := performRequest(GetRouter(), "GET", "/schemas/CarExample", nil, map[string]string{"Accept-Language": "es"})
+		assert.Equal(t, http.StatusOK, req.Code)
+		assert.Equal(t, "es", req.Header().Get("Content-Language"))
+		s := JSONSchema{}
+		if err := json.Unmarshal(req.Body.Bytes(), &s); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "Marca es obligatorio", s.Properties["Make"].Messages["required"])
+	})
+
+	t.Run("struct and async rules", func(t *testing.T) {
+		session := JSONSchemaFor(models.StudioSessionExample{})
+		assert.Equal(t, "End time must be no more than 8 hours after the start time", session.Properties["EndTime"].Messages["max_session=8h0m0s"])
+
+		password := NegotiateLocale("es").JSONSchema(models.PasswordExample{})
+		assert.Equal(t, "Nombre de usuario ya está en uso", password.Properties["Username"].Messages["available=usernames"])
+	})
+
+	t.Run("unknown model", func(t *testing.T) {
+		req := performRequest(GetRouter(), "GET", "/schemas/Nope", nil, nil)
+		assert.Equal(t, http.StatusNotFound, req.Code)
+	})
+}
@@ -1,18 +1,12 @@
 package controllers
 
-import (
-	"github.com/gin-gonic/gin"
-	"github.com/mike-webster/golang-validation/models"
-)
+import "github.com/mike-webster/golang-validation/models"
 
 // leadHandler will handle POST requests to /lead
-func leadHandler(c *gin.Context) {
-	var lead models.LeadSourceExample
-	err := c.Bind(&lead)
-	if err != nil {
-		c.Set("controllerError", true)
-		return
-	}
+var leadHandler = BindHandler(FromBody, respondOK[models.LeadSourceExample])
 
-	c.Status(200)
-}
+// leadBatchHandler will handle POST requests to /lead/batch
+var leadBatchHandler = BatchHandler[models.LeadSourceExample]()
+
+// leadCSVHandler will handle POST requests to /lead/csv
+var leadCSVHandler = CSVHandler[models.LeadSourceExample]()
@@ -14,7 +14,7 @@ func TestPostLead(t *testing.T) {
 				Path:        "/lead",
 				ExpCode:     400,
 				ExpFields:   []string{"VisitorID"},
-				ExpMessages: []string{"Visitor id is required"},
+				ExpMessages: []string{"Visitor ID is required"},
 				Body: models.LeadSourceExample{
 					Source: "google",
 				},
@@ -24,7 +24,7 @@ func TestPostLead(t *testing.T) {
 				Path:        "/lead",
 				ExpCode:     400,
 				ExpFields:   []string{"VisitorID"},
-				ExpMessages: []string{"Visitor id is not a valid uuidv4"},
+				ExpMessages: []string{"Visitor ID is not a valid uuidv4"},
 				Body: models.LeadSourceExample{
 					VisitorID: "not-valid-uuid",
 					Source:    "google",
@@ -51,6 +51,27 @@ func TestPostLead(t *testing.T) {
 					Source:    "not-a-valid-source",
 				},
 			},
+			testCase{
+				Name:        "source-other-without-detail",
+				Path:        "/lead",
+				ExpCode:     400,
+				ExpFields:   []string{"SourceDetail"},
+				ExpMessages: []string{"Source detail is required when Source is other"},
+				Body: models.LeadSourceExample{
+					VisitorID: "f6a91ca9-a517-458a-80f1-2e31b58f9cc2",
+					Source:    "other",
+				},
+			},
+			testCase{
+				Name:    "source-other-with-detail",
+				Path:    "/lead",
+				ExpCode: 200,
+				Body: models.LeadSourceExample{
+					VisitorID:    "f6a91ca9-a517-458a-80f1-2e31b58f9cc2",
+					Source:       "other",
+					SourceDetail: "a friend told me",
+				},
+			},
 			testCase{
 				Name:    "success",
 				Path:    "/lead",
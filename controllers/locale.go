@@ -0,0 +1,462 @@
+package controllers
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DefaultLocale is the locale of the Messages catalog, and the last stop
+// when looking for a translation.
+const DefaultLocale = "en"
+
+// PluralRule returns the plural category ("one", "other", etc) a count
+// falls into for a given language.
+type PluralRule func(n int) string
+
+// PluralOneOther is used by languages with a singular and a plural form,
+// like English, Spanish and German.
+func PluralOneOther(n int) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// PluralNone is used by languages that don't inflect for count, like Japanese.
+func PluralNone(n int) string {
+	return "other"
+}
+
+// PluralRules are the rules a locale file can refer to by name.
+var PluralRules = map[string]PluralRule{
+	"one_other": PluralOneOther,
+	"none":      PluralNone,
+}
+
+//go:embed locales/*.json
+var embeddedLocales embed.FS
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]*MessageCatalog{DefaultLocale: Messages}
+)
+
+func init() {
+	files, err := embeddedLocales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range files {
+		bs, err := embeddedLocales.ReadFile("locales/" + f.Name())
+		if err != nil {
+			panic(err)
+		}
+		if err := LoadLocale(bs, json.Unmarshal); err != nil {
+			panic(fmt.Sprint(f.Name(), ": ", err))
+		}
+	}
+}
+
+// localeFile is the format of a locale bundle on disk.
+type localeFile struct {
+	Locale   string                       `json:"locale" yaml:"locale"`
+	Plural   string                       `json:"plural" yaml:"plural"`
+	Messages map[string]string            `json:"messages" yaml:"messages"`
+	Fields   map[string]string            `json:"fields" yaml:"fields"`
+	Units    map[string]map[string]string `json:"units" yaml:"units"`
+	Months   []string                     `json:"months" yaml:"months"`
+}
+
+// RegisterLocale will add or replace the catalog used for the given locale.
+func RegisterLocale(locale string, m *MessageCatalog) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[normalizeLocale(locale)] = m
+}
+
+// LocaleCatalog will return the catalog registered for the given locale.
+func LocaleCatalog(locale string) (*MessageCatalog, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	m, ok := locales[normalizeLocale(locale)]
+	return m, ok
+}
+
+// LoadLocaleFile will load a locale bundle from a .json, .yaml or .yml file.
+func LoadLocaleFile(path string) error {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch filepath.Ext(path) {
+	case ".json":
+		return LoadLocale(bs, json.Unmarshal)
+	case ".yaml", ".yml":
+		return LoadLocale(bs, yaml.Unmarshal)
+	}
+	return fmt.Errorf("unsupported locale file: %s", path)
+}
+
+// LoadLocale will decode a locale bundle with the given unmarshal func and
+// merge it into the catalog for its locale, creating one if needed.
+func LoadLocale(bs []byte, unmarshal func([]byte, interface{}) error) error {
+	var lf localeFile
+	if err := unmarshal(bs, &lf); err != nil {
+		return err
+	}
+	if lf.Locale == "" {
+		return fmt.Errorf("locale is required")
+	}
+
+	m, ok := LocaleCatalog(lf.Locale)
+	if !ok {
+		rule := PluralOneOther
+		if lf.Plural != "" {
+			rule, ok = PluralRules[lf.Plural]
+			if !ok {
+				return fmt.Errorf("unknown plural rule: %s", lf.Plural)
+			}
+		}
+		m = newCatalog(rule)
+		RegisterLocale(lf.Locale, m)
+	}
+	for tag, tmpl := range lf.Messages {
+		m.Register(tag, tmpl)
+	}
+	for field, label := range lf.Fields {
+		m.RegisterField(field, label)
+	}
+	if len(lf.Months) > 0 {
+		if len(lf.Months) != 12 {
+			return fmt.Errorf("months needs 12 names, got %d", len(lf.Months))
+		}
+		m.RegisterMonths(lf.Months)
+	}
+	for unit, words := range lf.Units {
+		for category, word := range words {
+			m.RegisterUnit(unit, category, word)
+		}
+	}
+	return nil
+}
+
+// Localizer renders messages from a chain of catalogs, using the first
+// one that has a translation and falling back through the rest.
+type Localizer struct {
+	// Locale is the locale that was negotiated, for the Content-Language header
+	Locale   string
+	catalogs []*MessageCatalog
+}
+
+// localizer will return a Localizer that only uses this catalog.
+func (m *MessageCatalog) localizer() *Localizer {
+	return &Localizer{Locale: DefaultLocale, catalogs: []*MessageCatalog{m}}
+}
+
+// NegotiateLocale will build a Localizer from an Accept-Language header.
+// The preferred languages are tried in order of their q value, each one
+// falling back to its base language (ex: es-MX -> es), and then to English.
+func NegotiateLocale(acceptLanguage string) *Localizer {
+	l := &Localizer{}
+	seen := map[*MessageCatalog]bool{}
+	add := func(locale string) {
+		m, ok := LocaleCatalog(locale)
+		if !ok || seen[m] {
+			return
+		}
+		if l.Locale == "" {
+			l.Locale = normalizeLocale(locale)
+		}
+		seen[m] = true
+		l.catalogs = append(l.catalogs, m)
+	}
+
+	for _, locale := range parseAcceptLanguage(acceptLanguage) {
+		add(locale)
+		if i := strings.Index(locale, "-"); i > 0 {
+			add(locale[:i])
+		}
+	}
+	add(DefaultLocale)
+	return l
+}
+
+// parseAcceptLanguage will return the locales in the header, most
+// preferred first.
+func parseAcceptLanguage(header string) []string {
+	type pref struct {
+		locale string
+		q      float64
+	}
+	prefs := []pref{}
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		locale := normalizeLocale(fields[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			prefs = append(prefs, pref{locale, q})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+
+	ret := []string{}
+	for _, p := range prefs {
+		ret = append(ret, p.locale)
+	}
+	return ret
+}
+
+// normalizeLocale will turn "es_MX" and "es-mx" into "es-mx".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(locale), "_", "-", -1))
+}
+
+// Render will build the readable message for the given field error.
+func (l *Localizer) Render(e validator.FieldError) string {
+	fe := newFieldError(e)
+	return l.render(fe, errorPath(fe), nil)
+}
+
+// render will build the readable message for the field error, using the
+// labels from the resolved path and paramLabels for the fields in the
+// param, keyed by field name.
+func (l *Localizer) render(e *fieldError, path fieldPath, paramLabels map[string]string) string {
+	fields, values := paramFields(e.Tag, e.Param)
+	labels := make([]string, len(fields))
+	for i, f := range fields {
+		labels[i] = l.label(f, paramLabels[f])
+	}
+	for i, v := range values {
+		values[i] = l.formatParam(e.Tag, v)
+	}
+	r := strings.NewReplacer(
+		"{field}", l.pathLabel(path),
+		"{paramField}", strings.Join(labels, paramJoiner(e.Tag)),
+		"{paramValue}", strings.Join(values, ", "),
+		"{param}", l.formatParam(e.Tag, e.Param),
+		"{unit}", l.unit(e),
+	)
+	return r.Replace(l.template(e))
+}
+
+// formatParam will turn a tag's param into something a person would
+// write, in the localizer's language - dates, durations, file sizes and
+// layouts are shown the long way.
+func (l *Localizer) formatParam(tag string, param string) string {
+	if isDurationParam(tag) {
+		if d, err := time.ParseDuration(param); err == nil {
+			return l.duration(d)
+		}
+		return param
+	}
+	switch tag {
+	case "after", "before":
+		if t, err := parseDateParam(tag, param); err == nil {
+			return l.date(t)
+		}
+		return param
+	case "businesshours":
+		return l.businessHours(param)
+	case "datetime":
+		return humanLayout(param)
+	case "maxsize":
+		if n, err := parseSize(param); err == nil {
+			return l.size(n)
+		}
+	case "maxdimensions", "mindimensions":
+		if width, height, err := parseDimensions(tag, param); err == nil {
+			return fmt.Sprintf("%d × %d", width, height)
+		}
+	case "mimetypes":
+		return strings.Join(strings.Fields(param), ", ")
+	}
+	return param
+}
+
+// template will return the first template found for the field error's
+// tag - preferring one for the kind of field (ex: gtfield:time) in any
+// catalog over the plain tag, so the wording for the kind isn't lost to a
+// translation of the plain tag - or the first fallback template if no
+// catalog has the tag.
+func (l *Localizer) template(e *fieldError) string {
+	if kind := templateKind(e); kind != "" {
+		for _, m := range l.catalogs {
+			if tmpl, ok := m.Template(e.Tag + ":" + kind); ok {
+				return tmpl
+			}
+		}
+	}
+	for _, m := range l.catalogs {
+		if tmpl, ok := m.Template(e.Tag); ok {
+			return tmpl
+		}
+	}
+	for _, m := range l.catalogs {
+		if tmpl, ok := m.Template(fallbackTag); ok {
+			return tmpl
+		}
+	}
+	return defaultTemplates[fallbackTag]
+}
+
+// templateKind will return the kind of field the error is for, when a
+// tag needs different wording for it, ex: "time" for a time.Time or
+// "number" for an int. Strings don't have a kind, they use the tag's
+// own template.
+func templateKind(e *fieldError) string {
+	if e.Type == timeType {
+		return "time"
+	}
+	switch e.Kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "collection"
+	}
+	return ""
+}
+
+// label will return the display name for a field - a translation if
+// there is one, then the field's label tag, then its humanized name.
+func (l *Localizer) label(field string, labelTag string) string {
+	if label, ok := l.field(field); ok {
+		return label
+	}
+	if labelTag != "" {
+		return labelTag
+	}
+	return Humanize(field)
+}
+
+// field will return the first display name registered for a field.
+func (l *Localizer) field(field string) (string, bool) {
+	for _, m := range l.catalogs {
+		if label, ok := m.field(field); ok {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// pathLabel will return the display name for the field at the end of the
+// path, including its parents and the entries needed to find it
+// (ex: Items[2].Name -> Items entry 3 name).
+func (l *Localizer) pathLabel(p fieldPath) string {
+	label := ""
+	nested := false
+	for i, s := range p {
+		if s.IsIndex {
+			label = l.indexLabel(label, s, nested)
+			nested = true
+			continue
+		}
+		name := l.label(s.Name, s.Label)
+		if _, ok := l.field(s.Name); !ok && s.Label == "" && i > 0 {
+			name = humanize(s.Name, false)
+		}
+		if label != "" {
+			name = label + " " + name
+		}
+		label = name
+		nested = false
+	}
+	return label
+}
+
+// indexLabel will add the entry a path segment points at to the label of
+// its parent. Positions count from 1 the way a person would (ex: Artist[1]
+// -> Artist entry 2), an entry of an entry is an item (ex: Content[0][2]
+// -> Content entry 1, item 3), and map keys are quoted as they are.
+func (l *Localizer) indexLabel(parent string, s pathSegment, nested bool) string {
+	key, index := "path_index", s.Index
+	n, err := strconv.Atoi(s.Index)
+	switch {
+	case s.IsKey || (err != nil && s.Index != indexPlaceholder):
+		key = "path_key"
+	case nested:
+		key = "path_item"
+	}
+	if err == nil && key != "path_key" {
+		index = strconv.Itoa(n + 1)
+	}
+	return strings.NewReplacer("{field}", parent, "{index}", index).Replace(l.text(key))
+}
+
+// text will return the first template found for the given catalog key,
+// falling back to the default one.
+func (l *Localizer) text(key string) string {
+	for _, m := range l.catalogs {
+		if tmpl, ok := m.Template(key); ok {
+			return tmpl
+		}
+	}
+	return defaultTemplates[key]
+}
+
+// unit will return the word for whatever is being counted by the field
+// error, pluralized for the count in its param.
+func (l *Localizer) unit(e *fieldError) string {
+	var unit string
+	switch {
+	case e.Type == fileListType:
+		unit = "file"
+	case e.Kind == reflect.Slice, e.Kind == reflect.Array:
+		unit = "entry"
+	case e.Kind == reflect.Map:
+		unit = "key"
+	case e.Kind == reflect.String:
+		unit = "character"
+	default:
+		// numbers and the like are compared by value, so there's
+		// nothing being counted
+		return ""
+	}
+
+	n, _ := strconv.Atoi(e.Param)
+	return l.unitWord(unit, n)
+}
+
+// unitWord will return the word for the unit, pluralized for n.
+func (l *Localizer) unitWord(unit string, n int) string {
+	for _, m := range l.catalogs {
+		if word, ok := m.unit(unit, n); ok {
+			return word
+		}
+	}
+	return unit
+}
+
+// month will return the name of the month.
+func (l *Localizer) month(month time.Month) string {
+	for _, m := range l.catalogs {
+		if name, ok := m.month(month); ok {
+			return name
+		}
+	}
+	return month.String()
+}
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestLocalizedMessages(t *testing.T) {
+	t.Run("LocaleTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "spanish",
+				Path:        "/album",
+				ExpCode:     400,
+				ExpFields:   []string{"Artist"},
+				ExpMessages: []string{"Artista debe contener al menos 1 entrada"},
+				Headers:     map[string]string{"Accept-Language": "es"},
+				Body:        models.AlbumExample{Artist: []string{}, Name: "dude ranch"},
+			},
+			testCase{
+				Name:        "spanish-entry",
+				Path:        "/album",
+				ExpCode:     400,
+				ExpFields:   []string{"Artist[0]"},
+				ExpMessages: []string{"Artista n.º 1 debe contener al menos 2 caracteres"},
+				Headers:     map[string]string{"Accept-Language": "es"},
+				Body:        models.AlbumExample{Artist: []string{"a"}, Name: "dude ranch"},
+			},
+			testCase{
+				Name:        "german-plural",
+				Path:        "/album",
+				ExpCode:     400,
+				ExpFields:   []string{"Artist"},
+				ExpMessages: []string{"Künstler darf höchstens 5 Einträge enthalten"},
+				Headers:     map[string]string{"Accept-Language": "de-DE,de;q=0.9,en;q=0.5"},
+				Body: models.AlbumExample{
+					Artist: []string{"fda", "fda", "fdas", "fdas", "fdas", "fda"},
+					Name:   "dude ranch",
+				},
+			},
+			testCase{
+				Name:        "japanese",
+				Path:        "/password",
+				ExpCode:     400,
+				ExpFields:   []string{"PasswordConfirm"},
+				ExpMessages: []string{"パスワード（確認）はパスワードと一致する必要があります"},
+				Headers:     map[string]string{"Accept-Language": "ja"},
+				Body: models.PasswordExample{
+					Username:        "fdsafdfdsfds",
+					Password:        "testpass",
+					PasswordConfirm: "oldtestpass",
+					OldPassword:     "oldtestpass",
+				},
+			},
+			testCase{
+				Name:        "spanish-query",
+				Method:      "GET",
+				Path:        "/cars?sort=price",
+				ExpCode:     400,
+				ExpFields:   []string{"query.sort"},
+				ExpMessages: []string{"Sort debe ser uno de: make model year -make -model -year"},
+				Headers:     map[string]string{"Accept-Language": "es"},
+			},
+			testCase{
+				Name:        "unsupported-falls-back-to-english",
+				Path:        "/car",
+				ExpCode:     400,
+				ExpFields:   []string{"Make"},
+				ExpMessages: []string{"Make is required"},
+				Headers:     map[string]string{"Accept-Language": "fr-CA, fr;q=0.8"},
+				Body:        models.CarExample{Model: "test model"},
+			},
+		}
+		runTests(t, tests, GetRouter())
+	})
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	t.Run("QualityOrder", func(t *testing.T) {
+		l := NegotiateLocale("en;q=0.1, ja;q=0.5, de")
+		assert.Equal(t, "de", l.Locale)
+	})
+	t.Run("BaseLanguage", func(t *testing.T) {
+		l := NegotiateLocale("es-MX")
+		assert.Equal(t, "es", l.Locale)
+	})
+	t.Run("MissingTranslation", func(t *testing.T) {
+		bs, _ := json.Marshal(map[string]interface{}{
+			"locale":   "nl",
+			"messages": map[string]string{"required": "{field} is verplicht"},
+		})
+		assert.Equal(t, nil, LoadLocale(bs, json.Unmarshal))
+
+		e := fieldErrorFor(t, struct {
+			Color string `binding:"hexcolor"`
+		}{Color: "nope"}, "Color")
+		assert.Equal(t, "Color must be a valid hex color", NegotiateLocale("nl").Render(e))
+	})
+	t.Run("ModelTagsTranslated", func(t *testing.T) {
+		for _, locale := range []string{"es", "de", "ja"} {
+			m, _ := LocaleCatalog(locale)
+			for _, model := range registeredModels() {
+				if reflect.TypeOf(model).PkgPath() != reflect.TypeOf(models.CarExample{}).PkgPath() {
+					// a model from a test
+					continue
+				}
+				for _, tag := range modelTags(reflect.TypeOf(model), map[reflect.Type]bool{}) {
+					_, ok := m.Template(tag)
+					assert.T(t, ok, fmt.Sprintf("%s is missing %s, used by %T", locale, tag, model))
+				}
+			}
+		}
+	})
+	t.Run("TimeTranslations", func(t *testing.T) {
+		timeTags := []string{"future", "past", "after", "before", "withinfield", "businesshours"}
+		for tag := range Messages.templates {
+			if strings.Contains(tag, ":") {
+				timeTags = append(timeTags, tag)
+			}
+		}
+		for _, locale := range []string{"es", "de", "ja"} {
+			m, _ := LocaleCatalog(locale)
+			for _, tag := range timeTags {
+				_, ok := m.templates[tag]
+				assert.T(t, ok, locale+" is missing "+tag)
+			}
+			assert.Equal(t, 12, len(m.months), locale)
+		}
+	})
+	t.Run("LoadLocale", func(t *testing.T) {
+		bs, _ := json.Marshal(map[string]interface{}{
+			"locale":   "pt-BR",
+			"messages": map[string]string{"required": "{field} é obrigatório"},
+			"fields":   map[string]string{"Make": "Marca"},
+		})
+		assert.Equal(t, nil, LoadLocale(bs, json.Unmarshal))
+
+		e := fieldErrorFor(t, struct {
+			Make string `binding:"required"`
+		}{}, "Make")
+		assert.Equal(t, "Marca é obrigatório", NegotiateLocale("pt-BR").Render(e))
+		assert.Equal(t, "pt-br", NegotiateLocale("pt-BR").Locale)
+	})
+}
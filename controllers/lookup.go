@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Lookup answers whether a value is already in a collection, ex: whether
+// a username is in the users table. Implementations should give up when
+// ctx is done.
+type Lookup interface {
+	Exists(ctx context.Context, collection string, value string) (bool, error)
+}
+
+// Store is the Lookup the available and exists tags check against. Swap
+// it for one backed by a real datastore before calling GetRouter.
+var Store Lookup = NewMemoryLookup()
+
+func init() {
+	RegisterAsyncValidation("available", isAvailable, "{field} is already taken")
+	RegisterAsyncValidation("exists", exists, "{field} was not found")
+	Messages.Register(tagAsyncTimeout, "{field} could not be checked in time, please try again")
+	Messages.Register(tagAsyncUnavailable, "{field} could not be checked right now, please try again")
+}
+
+// isAvailable will check the value isn't in the collection named in
+// param, ex: async:"available=usernames"
+func isAvailable(ctx context.Context, value interface{}, param string) (bool, error) {
+	found, err := Store.Exists(ctx, param, fmt.Sprint(value))
+	return !found, err
+}
+
+// exists will check the value is in the collection named in param,
+// ex: async:"exists=visitors"
+func exists(ctx context.Context, value interface{}, param string) (bool, error) {
+	return Store.Exists(ctx, param, fmt.Sprint(value))
+}
+
+// MemoryLookup is a Lookup that keeps everything in memory - handy for
+// tests and examples.
+type MemoryLookup struct {
+	mu          sync.RWMutex
+	collections map[string]map[string]bool
+}
+
+// NewMemoryLookup will return an empty MemoryLookup.
+func NewMemoryLookup() *MemoryLookup {
+	return &MemoryLookup{collections: map[string]map[string]bool{}}
+}
+
+// Add will put the values in the named collection.
+func (m *MemoryLookup) Add(collection string, values ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.collections[collection] == nil {
+		m.collections[collection] = map[string]bool{}
+	}
+	for _, v := range values {
+		m.collections[collection][v] = true
+	}
+}
+
+// Remove will take the values out of the named collection.
+func (m *MemoryLookup) Remove(collection string, values ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range values {
+		delete(m.collections[collection], v)
+	}
+}
+
+// Exists will check if the value is in the named collection.
+func (m *MemoryLookup) Exists(ctx context.Context, collection string, value string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.collections[collection][value], nil
+}
@@ -1,23 +1,199 @@
 package controllers
 
-import "github.com/gin-gonic/gin"
+import (
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/mike-webster/golang-validation/models"
+)
 
 var router *gin.Engine
 
+// route is an endpoint GetRouter serves. The model is what the handler
+// binds, and is used to document the route.
+type route struct {
+	Method  string
+	Path    string
+	Source  Source
+	Model   interface{}
+	Handler gin.HandlerFunc
+}
+
+// routes are every endpoint that validates a model.
+var routes = []route{
+	{http.MethodPost, "/car", FromBody, models.CarExample{}, carHandler},
+	{http.MethodPost, "/album", FromBody, models.AlbumExample{}, albumHandler},
+	{http.MethodPost, "/password", FromBody, models.PasswordExample{}, passwordHandler},
+	{http.MethodPost, "/lead", FromBody, models.LeadSourceExample{}, leadHandler},
+	{http.MethodPost, "/studio-session", FromBody, models.StudioSessionExample{}, studioSessionHandler},
+	{http.MethodPost, "/signup", FromBody, models.SignupExample{}, signupHandler},
+	{http.MethodPost, "/partnership-request", FromBody, models.PartnershipRequestExample{}, partnershipRequestHandler},
+	{http.MethodPost, "/coordinates", FromBody, models.PostCoordinatesExample{}, coordinatesHandler},
+	{http.MethodPost, "/upload-csvs", FromBody, models.UploadCsvsExample{}, uploadCsvsHandler},
+	{http.MethodPost, "/profile", FromForm, models.ProfileUploadExample{}, profileHandler},
+	{http.MethodGet, "/cars", FromRequest, models.CarSearchExample{}, carSearchHandler},
+	{http.MethodGet, "/cars/:id", FromRequest, models.CarLookupExample{}, carLookupHandler},
+}
+
+// modelName is the name of the model's type, which the spec and the
+// schema and validate routes know it by.
+func (rt route) modelName() string {
+	return reflect.TypeOf(rt.Model).Name()
+}
+
+// patchable is whether the route also takes a PATCH with only the fields
+// being changed - that's any route bound from a JSON body.
+func (rt route) patchable() bool {
+	return (rt.Source == FromBody || rt.Source == FromJSON) && rt.Method != http.MethodPatch
+}
+
+// batchRoutes are the endpoints that validate a batch of a model, at
+// their path plus BatchSuffix.
+var batchRoutes = []route{
+	{http.MethodPost, "/lead", FromJSON, models.LeadSourceExample{}, leadBatchHandler},
+}
+
+// csvRoutes are the endpoints that validate a CSV upload of a model, at
+// their path plus CSVSuffix.
+var csvRoutes = []route{
+	{http.MethodPost, "/lead", FromForm, models.LeadSourceExample{}, leadCSVHandler},
+}
+
+// RouterOption changes how GetRouter builds the router.
+type RouterOption func(*routerConfig)
+
+type routerConfig struct {
+	mode          ResponseMode
+	problemRoutes map[string]bool
+	paths         PathFormat
+	batchLimit    int
+	rejectUnknown bool
+	strictRoutes  map[string]bool
+}
+
+// WithProblemDetails will make validation errors come back as
+// application/problem+json. With no paths it applies to every route,
+// otherwise only to the given paths.
+func WithProblemDetails(paths ...string) RouterOption {
+	return func(cfg *routerConfig) {
+		if len(paths) == 0 {
+			cfg.mode = ResponseProblem
+			return
+		}
+		for _, p := range paths {
+			cfg.problemRoutes[p] = true
+		}
+	}
+}
+
+// WithPathFormat will change how nested field paths are written in the
+// error keys, ex: PathFormat{JSONPointer: true} for /Artist/2
+func WithPathFormat(f PathFormat) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.paths = f
+	}
+}
+
+// WithBatchLimit will change the most records a batch can have from
+// DefaultBatchLimit.
+func WithBatchLimit(limit int) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.batchLimit = limit
+	}
+}
+
+// WithUnknownFieldsRejected will make keys in a JSON body that the model
+// doesn't have an error, the way StrictJSON does, instead of ignoring
+// them. With no paths it applies to every route, otherwise only to the
+// given paths.
+func WithUnknownFieldsRejected(paths ...string) RouterOption {
+	return func(cfg *routerConfig) {
+		if len(paths) == 0 {
+			cfg.rejectUnknown = true
+			return
+		}
+		for _, p := range paths {
+			cfg.strictRoutes[p] = true
+		}
+	}
+}
+
+// handlers will return the handler chain for the given route.
+func (cfg *routerConfig) handlers(path string, h gin.HandlerFunc) []gin.HandlerFunc {
+	chain := []gin.HandlerFunc{}
+	if cfg.problemRoutes[path] {
+		chain = append(chain, ProblemDetails())
+	}
+	if cfg.strictRoutes[path] {
+		chain = append(chain, RejectUnknownFields())
+	}
+	return append(chain, h)
+}
+
 // GetRouter will return a configured router
-func GetRouter() *gin.Engine {
-	if router != nil {
+func GetRouter(opts ...RouterOption) *gin.Engine {
+	if router != nil && len(opts) == 0 {
 		// I'm only cacheing this to avoid recreating the router
 		// a bunch of times for tests
 		return router
 	}
+	cfg := &routerConfig{
+		problemRoutes: map[string]bool{},
+		paths:         DefaultPathFormat,
+		batchLimit:    DefaultBatchLimit,
+		strictRoutes:  map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	checkMessages(registeredModels()...)
+	binding.Validator = modelValidator
 	r := gin.Default()
 	r.Use(mwLogBody())
-	r.Use(mwParseValidation())
-	r.POST("/car", carHandler)
-	r.POST("/album", albumHandler)
-	r.POST("/password", passwordHandler)
-	r.POST("/lead", leadHandler)
-	router = r
-	return router
+	r.Use(mwParseValidation(cfg))
+	r.Use(mwDryRun())
+	for _, rt := range routes {
+		methods := []string{rt.Method}
+		if rt.patchable() {
+			methods = append(methods, http.MethodPatch)
+		}
+		for _, method := range methods {
+			r.Handle(method, rt.Path, cfg.handlers(rt.Path, rt.Handler)...)
+			if strings.ContainsAny(rt.Path, ":*") {
+				// the validate route wouldn't have the path params to bind
+				continue
+			}
+			r.Handle(method, ValidatePrefix+"/"+rt.modelName(), append([]gin.HandlerFunc{DryRun()}, cfg.handlers(rt.Path, rt.Handler)...)...)
+		}
+	}
+	for _, rt := range batchRoutes {
+		r.Handle(rt.Method, rt.Path+BatchSuffix, cfg.handlers(rt.Path+BatchSuffix, rt.Handler)...)
+	}
+	for _, rt := range csvRoutes {
+		r.Handle(rt.Method, rt.Path+CSVSuffix, cfg.handlers(rt.Path+CSVSuffix, rt.Handler)...)
+	}
+	r.GET(OpenAPIPath, serveOpenAPI(buildOpenAPI(cfg, routes, batchRoutes, csvRoutes)))
+	r.GET(SchemasPath, serveSchemas(routes))
+	if len(opts) == 0 {
+		router = r
+	}
+	return r
+}
+
+// checkMessages will make sure every tag used by the given models has a
+// message, and a param it can read, so we find out about it now instead of
+// from a confused user.
+func checkMessages(models ...interface{}) {
+	missing := Messages.MissingTags(models...)
+	if len(missing) > 0 {
+		log.Panicf("no validation message registered for tag(s): %s", strings.Join(missing, ", "))
+	}
+	if bad := BadParams(models...); len(bad) > 0 {
+		log.Panicf("bad validation param(s): %s", strings.Join(bad, ", "))
+	}
 }
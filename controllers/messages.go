@@ -0,0 +1,489 @@
+package controllers
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// bindingTag is the struct tag gin reads validation rules from.
+const bindingTag = "binding"
+
+// fallbackTag is the catalog entry used for any tag that doesn't have a
+// template, and for OR'd tags (ex: eq=google|eq=yahoo).
+const fallbackTag = "*"
+
+// MessageCatalog maps validator tags to message templates.
+//
+// Templates can use the following placeholders:
+// - {field}      => the readable field name (ex: OldPassword -> Old password)
+// - {param}      => the tag's param as written (ex: the 5 in lte=5)
+// - {paramField} => the other field(s) named in the param (ex: eqfield, required_with)
+// - {paramValue} => the value(s) in the param for required_if and required_unless
+// - {unit}       => the unit being counted (ex: characters, entries)
+//
+// Dates, durations, file sizes and date layouts in {param} and
+// {paramValue} are written out the long way (ex: 8h -> 8 hours), using the
+// catalog's date, date_time, clock (a Go time layout), business_days,
+// count, duration_separator and duration_and entries, its month names,
+// and its day, hour, minute, second and byte units.
+//
+// A tag can have a template for a kind of field as well, which is used
+// instead of the tag's template for those fields, ex: gtfield:time
+//
+// The path_index, path_item and path_key entries say how an entry of a
+// slice, array or map is named in {field}, ex: Artist entry 2
+//
+// A catalog also holds the words for each unit, keyed by plural category,
+// and optional display names for fields (ex: OldPassword -> Contraseña anterior).
+type MessageCatalog struct {
+	mu        sync.RWMutex
+	templates map[string]string
+	fields    map[string]string
+	units     map[string]map[string]string
+	months    []string
+	plural    PluralRule
+}
+
+// Messages is the catalog ValidationErrorToText renders from. Register any
+// overrides or new tags on it before calling GetRouter.
+var Messages = NewMessageCatalog()
+
+// NewMessageCatalog will return a catalog pre-loaded with a template for
+// every validator baked into validator v10.
+func NewMessageCatalog() *MessageCatalog {
+	m := newCatalog(PluralOneOther)
+	for tag, tmpl := range defaultTemplates {
+		m.templates[tag] = tmpl
+	}
+	m.units["character"] = map[string]string{"one": "character", "other": "characters"}
+	m.units["entry"] = map[string]string{"one": "entry", "other": "entries"}
+	m.units["key"] = map[string]string{"one": "key", "other": "keys"}
+	m.units["file"] = map[string]string{"one": "file", "other": "files"}
+	m.units["byte"] = map[string]string{"one": "byte", "other": "bytes"}
+	for _, u := range durationUnits {
+		m.units[u.unit] = map[string]string{"one": u.unit, "other": u.unit + "s"}
+	}
+	return m
+}
+
+// newCatalog will return an empty catalog that pluralizes with the given rule.
+func newCatalog(plural PluralRule) *MessageCatalog {
+	return &MessageCatalog{
+		templates: map[string]string{},
+		fields:    map[string]string{},
+		units:     map[string]map[string]string{},
+		plural:    plural,
+	}
+}
+
+// Register will add or replace the template used for the given tag.
+func (m *MessageCatalog) Register(tag string, template string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.templates[tag] = template
+}
+
+// RegisterField will set the display name used for the given field
+// instead of splitting its name.
+func (m *MessageCatalog) RegisterField(field string, label string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fields[field] = label
+}
+
+// RegisterUnit will set the word used for the given unit when the count
+// falls in the given plural category (ex: "entry", "other", "entries").
+func (m *MessageCatalog) RegisterUnit(unit string, category string, word string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.units[unit] == nil {
+		m.units[unit] = map[string]string{}
+	}
+	m.units[unit][category] = word
+}
+
+// RegisterMonths will set the names of the months used in dates, starting
+// with January.
+func (m *MessageCatalog) RegisterMonths(names []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.months = append([]string{}, names...)
+}
+
+// Template will return the template registered for the given tag.
+func (m *MessageCatalog) Template(tag string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tmpl, ok := m.templates[tag]
+	return tmpl, ok
+}
+
+// field will return the display name registered for the given field.
+func (m *MessageCatalog) field(name string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	label, ok := m.fields[name]
+	return label, ok
+}
+
+// unit will return the word for the given unit and count, using this
+// catalog's plural rule.
+func (m *MessageCatalog) unit(unit string, n int) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	words, ok := m.units[unit]
+	if !ok {
+		return "", false
+	}
+	if word, ok := words[m.plural(n)]; ok {
+		return word, true
+	}
+	word, ok := words["other"]
+	return word, ok
+}
+
+// month will return the name registered for the given month.
+func (m *MessageCatalog) month(month time.Month) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.months) != 12 {
+		return "", false
+	}
+	return m.months[month-1], true
+}
+
+// Render will build the readable message for the given field error, using
+// the default catalog for anything this one doesn't have.
+func (m *MessageCatalog) Render(e validator.FieldError) string {
+	l := &Localizer{Locale: DefaultLocale, catalogs: []*MessageCatalog{m}}
+	if m != Messages {
+		l.catalogs = append(l.catalogs, Messages)
+	}
+	return l.Render(e)
+}
+
+// MissingTags will walk the binding tags of the given models and return
+// every tag that doesn't have a template registered.
+func (m *MessageCatalog) MissingTags(models ...interface{}) []string {
+	seen := map[string]bool{}
+	for _, model := range models {
+		for _, tag := range modelTags(reflect.TypeOf(model), map[reflect.Type]bool{}) {
+			if _, ok := m.Template(tag); !ok {
+				seen[tag] = true
+			}
+		}
+	}
+
+	missing := []string{}
+	for tag := range seen {
+		missing = append(missing, tag)
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// BadParams will check the param of every tag on the given models that
+// has a param check, ex: maxsize=2XB, and return an error for each bad one.
+func BadParams(models ...interface{}) []string {
+	bad := []string{}
+	for _, model := range models {
+		for _, rule := range modelRules(reflect.TypeOf(model), map[reflect.Type]bool{}) {
+			parts := strings.SplitN(rule, "=", 2)
+			check, ok := paramChecks[parts[0]]
+			if !ok {
+				continue
+			}
+			param := ""
+			if len(parts) == 2 {
+				param = parts[1]
+			}
+			if err := check(param); err != nil {
+				bad = append(bad, err.Error())
+			}
+		}
+	}
+	sort.Strings(bad)
+	return bad
+}
+
+// modelTags will return the name of every validation tag used on the given
+// type, including the tags used on any nested structs.
+func modelTags(t reflect.Type, visited map[reflect.Type]bool) []string {
+	tags := []string{}
+	for _, rule := range modelRules(t, visited) {
+		tags = append(tags, strings.SplitN(rule, "=", 2)[0])
+	}
+	return tags
+}
+
+// modelRules will return every validation rule, with its param, used on
+// the given type, including the rules used on any nested structs.
+func modelRules(t reflect.Type, visited map[reflect.Type]bool) []string {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) || visited[t] {
+		return nil
+	}
+	visited[t] = true
+
+	rules := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		rules = append(rules, parseRules(f.Tag.Get(bindingTag))...)
+		rules = append(rules, parseRules(f.Tag.Get(asyncTag))...)
+		rules = append(rules, modelRules(f.Type, visited)...)
+	}
+	return rules
+}
+
+// parseRules will split a binding tag into its rules, splitting OR'd
+// rules apart and skipping the keywords that aren't validators themselves.
+func parseRules(binding string) []string {
+	rules := []string{}
+	if binding == "" || binding == "-" {
+		return rules
+	}
+	for _, rule := range strings.Split(binding, ",") {
+		for _, alt := range strings.Split(rule, "|") {
+			switch strings.SplitN(alt, "=", 2)[0] {
+			case "", "dive", "keys", "endkeys", "omitempty", "structonly", "nostructlevel":
+				continue
+			}
+			rules = append(rules, alt)
+		}
+	}
+	return rules
+}
+
+// defaultTemplates has a template for each of the validators baked into
+// validator v10, see: https://pkg.go.dev/github.com/go-playground/validator/v10
+var defaultTemplates = map[string]string{
+	fallbackTag: "{field} is not valid",
+
+	// presence and size
+	"required":  "{field} is required",
+	"len":       "{field} must contain exactly {param} {unit}",
+	"min":       "{field} must contain at least {param} {unit}",
+	"max":       "{field} must contain no more than {param} {unit}",
+	"eq":        "{field} must be equal to {param}",
+	"ne":        "{field} must not be equal to {param}",
+	"lt":        "{field} must contain fewer than {param} {unit}",
+	"lte":       "{field} must contain no more than {param} {unit}",
+	"gt":        "{field} must contain more than {param} {unit}",
+	"gte":       "{field} must contain at least {param} {unit}",
+	"unique":    "{field} must not contain duplicate values",
+	"oneof":     "{field} must be one of: {param}",
+	"isdefault": "{field} must not be set",
+
+	// required and excluded depending on other fields
+	"required_if":          "{field} is required when {paramField} is {paramValue}",
+	"required_unless":      "{field} is required unless {paramField} is {paramValue}",
+	"required_with":        "{field} is required when {paramField} is provided",
+	"required_with_all":    "{field} is required when {paramField} are provided",
+	"required_without":     "{field} is required when {paramField} is not provided",
+	"required_without_all": "{field} is required when {paramField} are not provided",
+	"excluded_with":        "{field} must not be set when {paramField} is provided",
+	"excluded_with_all":    "{field} must not be set when {paramField} are provided",
+	"excluded_without":     "{field} must not be set when {paramField} is not provided",
+	"excluded_without_all": "{field} must not be set when {paramField} are not provided",
+
+	// numbers are compared by value instead of counted
+	"len:number": "{field} must be {param}",
+	"min:number": "{field} must be at least {param}",
+	"max:number": "{field} must be at most {param}",
+	"lt:number":  "{field} must be less than {param}",
+	"lte:number": "{field} must be at most {param}",
+	"gt:number":  "{field} must be greater than {param}",
+	"gte:number": "{field} must be at least {param}",
+
+	// slices, arrays and maps are compared by length
+	"eq:collection": "{field} must contain exactly {param} {unit}",
+	"ne:collection": "{field} must not contain exactly {param} {unit}",
+
+	// times
+	"gt:time":       "{field} must be in the future",
+	"gte:time":      "{field} must not be in the past",
+	"lt:time":       "{field} must be in the past",
+	"lte:time":      "{field} must not be in the future",
+	"eqfield:time":  "{field} must be the same time as {paramField}",
+	"nefield:time":  "{field} must not be the same time as {paramField}",
+	"gtfield:time":  "{field} must be after {paramField}",
+	"gtefield:time": "{field} must not be before {paramField}",
+	"ltfield:time":  "{field} must be before {paramField}",
+	"ltefield:time": "{field} must not be after {paramField}",
+
+	// comparing against other fields
+	"eqfield":       "{field} must match {paramField}",
+	"nefield":       "{field} must not be the same as {paramField}",
+	"gtfield":       "{field} must be greater than {paramField}",
+	"gtefield":      "{field} must be greater than or equal to {paramField}",
+	"ltfield":       "{field} must be less than {paramField}",
+	"ltefield":      "{field} must be less than or equal to {paramField}",
+	"eqcsfield":     "{field} must match {paramField}",
+	"necsfield":     "{field} must not be the same as {paramField}",
+	"gtcsfield":     "{field} must be greater than {paramField}",
+	"gtecsfield":    "{field} must be greater than or equal to {paramField}",
+	"ltcsfield":     "{field} must be less than {paramField}",
+	"ltecsfield":    "{field} must be less than or equal to {paramField}",
+	"fieldcontains": "{field} must contain the value of {paramField}",
+	"fieldexcludes": "{field} must not contain the value of {paramField}",
+
+	// string contents
+	"alpha":           "{field} must contain only letters",
+	"alphanum":        "{field} must be alphanumeric",
+	"alphaunicode":    "{field} must contain only letters",
+	"alphanumunicode": "{field} must contain only letters and numbers",
+	"lowercase":       "{field} must be lowercase",
+	"uppercase":       "{field} must be uppercase",
+	"startswith":      "{field} must start with '{param}'",
+	"endswith":        "{field} must end with '{param}'",
+	"startsnotwith":   "{field} must not start with '{param}'",
+	"endsnotwith":     "{field} must not end with '{param}'",
+	"numeric":         "{field} must be a numeric value",
+	"number":          "{field} must be a number",
+	"hexadecimal":     "{field} must be a hexadecimal value",
+	"ascii":           "{field} must contain only ascii characters",
+	"printascii":      "{field} must contain only printable ascii characters",
+	"multibyte":       "{field} must contain multibyte characters",
+	"contains":        "{field} must contain '{param}'",
+	"containsany":     "{field} must contain at least one of '{param}'",
+	"containsrune":    "{field} must contain '{param}'",
+	"excludes":        "{field} must not be '{param}'",
+	"excludesall":     "{field} must not contain any of '{param}'",
+	"excludesrune":    "{field} must not contain '{param}'",
+	"html":            "{field} must be html",
+	"html_encoded":    "{field} must be html encoded",
+	"url_encoded":     "{field} must be url encoded",
+
+	// formats
+	"email":                   "Invalid email format",
+	"url":                     "{field} must be a valid url",
+	"uri":                     "{field} must be a valid uri",
+	"base64":                  "{field} must be valid base64",
+	"base64url":               "{field} must be valid base64url",
+	"urn_rfc2141":             "{field} must be a valid urn",
+	"file":                    "{field} must be an existing file",
+	"dir":                     "{field} must be an existing directory",
+	"json":                    "{field} must be valid json",
+	"e164":                    "{field} must be a valid e.164 phone number",
+	"datetime":                "{field} must be a date formatted as {param}",
+	"timezone":                "{field} must be a valid time zone",
+	"iso3166_1_alpha2":        "{field} must be a valid two letter country code",
+	"iso3166_1_alpha3":        "{field} must be a valid three letter country code",
+	"iso3166_1_alpha_numeric": "{field} must be a valid numeric country code",
+	"country_code":            "{field} must be a valid country code",
+	"eth_addr":                "{field} must be a valid ethereum address",
+	"btc_addr":                "{field} must be a valid bitcoin address",
+	"btc_addr_bech32":         "{field} must be a valid bech32 bitcoin address",
+	"datauri":                 "{field} must be a valid data uri",
+	"isbn":                    "{field} is not a valid isbn",
+	"isbn10":                  "{field} is not a valid isbn10",
+	"isbn13":                  "{field} is not a valid isbn13",
+	"uuid":                    "{field} is not a valid uuid",
+	"uuid3":                   "{field} is not a valid uuidv3",
+	"uuid4":                   "{field} is not a valid uuidv4",
+	"uuid5":                   "{field} is not a valid uuidv5",
+	"uuid_rfc4122":            "{field} is not a valid uuid",
+	"uuid3_rfc4122":           "{field} is not a valid uuidv3",
+	"uuid4_rfc4122":           "{field} is not a valid uuidv4",
+	"uuid5_rfc4122":           "{field} is not a valid uuidv5",
+	"latitude":                "{field} must be a valid latitude",
+	"longitude":               "{field} must be a valid longitude",
+	"ssn":                     "{field} must be a valid ssn",
+	"hexcolor":                "{field} must be a valid hex color",
+	"rgb":                     "{field} must be a valid rgb color",
+	"rgba":                    "{field} must be a valid rgba color",
+	"hsl":                     "{field} must be a valid hsl color",
+	"hsla":                    "{field} must be a valid hsla color",
+	"iscolor":                 "{field} must be a valid color",
+
+	// writing dates, times and durations in {param}
+	"date":               "{month} {day}, {year}",
+	"date_time":          "{date} at {time} {zone}",
+	"clock":              "3:04 PM",
+	"business_days":      "{start} and {end}, Monday to Friday ({zone})",
+	"count":              "{count} {unit}",
+	"duration_separator": ", ",
+	"duration_and":       " and ",
+
+	// naming the entries of slices, arrays and maps in {field}, where
+	// {field} is the parent and {index} is the position or the map key
+	"path_index": "{field} entry {index}",
+	"path_item":  "{field}, item {index}",
+	"path_key":   "{field} '{index}'",
+
+	// reading the request body, before validation happens
+	"body_empty":   "Request body is required",
+	"body_invalid": "Request body could not be read",
+	"body_type":    "Request body must be a JSON object",
+	"json_syntax":  "Request body is not valid JSON at {param}",
+	"json_type":    "{field} must be of type {param}",
+	"json_unknown": "{field} is not a recognized field",
+	"csv_syntax":   "Row is not valid CSV at {param}",
+	"batch_limit":  "A batch can't have more than {param} records",
+	"csv_limit":    "A CSV can't have more than {param} rows",
+
+	// network
+	"ip":               "{field} must be a valid ip address",
+	"ipv4":             "{field} must be a valid ipv4 address",
+	"ipv6":             "{field} must be a valid ipv6 address",
+	"cidr":             "{field} must be a valid cidr notation",
+	"cidrv4":           "{field} must be a valid ipv4 cidr notation",
+	"cidrv6":           "{field} must be a valid ipv6 cidr notation",
+	"tcp_addr":         "{field} must be a resolvable tcp address",
+	"tcp4_addr":        "{field} must be a resolvable tcp4 address",
+	"tcp6_addr":        "{field} must be a resolvable tcp6 address",
+	"udp_addr":         "{field} must be a resolvable udp address",
+	"udp4_addr":        "{field} must be a resolvable udp4 address",
+	"udp6_addr":        "{field} must be a resolvable udp6 address",
+	"ip_addr":          "{field} must be a resolvable ip address",
+	"ip4_addr":         "{field} must be a resolvable ipv4 address",
+	"ip6_addr":         "{field} must be a resolvable ipv6 address",
+	"unix_addr":        "{field} must be a resolvable unix address",
+	"mac":              "{field} must be a valid mac address",
+	"hostname":         "{field} must be a valid hostname",
+	"hostname_rfc1123": "{field} must be a valid hostname",
+	"hostname_port":    "{field} must be a valid host and port",
+	"fqdn":             "{field} must be a fully qualified domain name",
+}
+
+// paramFields will split the param of a tag that refers to other fields
+// into the field names and, for required_if and required_unless, the
+// values they're compared to (ex: "Source other" -> [Source], [other]).
+// Any other tag's param is treated as a single field name.
+func paramFields(tag string, param string) (fields []string, values []string) {
+	switch tag {
+	case "required_if", "required_unless":
+		parts := strings.Fields(param)
+		for i := 0; i+1 < len(parts); i += 2 {
+			fields = append(fields, parts[i])
+			values = append(values, parts[i+1])
+		}
+		return fields, values
+	case "withinfield":
+		parts := strings.SplitN(param, " ", 2)
+		if len(parts) == 2 {
+			return parts[:1], parts[1:]
+		}
+		return parts, nil
+	case "required_with", "required_with_all", "required_without", "required_without_all",
+		"excluded_with", "excluded_with_all", "excluded_without", "excluded_without_all":
+		return strings.Fields(param), nil
+	}
+	return []string{param}, nil
+}
+
+// paramJoiner is the word used between the fields in {paramField} - the
+// _all tags need every field, the rest need any one of them.
+func paramJoiner(tag string) string {
+	if strings.HasSuffix(tag, "_all") || tag == "required_if" {
+		return " and "
+	}
+	return " or "
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestMessageCatalog(t *testing.T) {
+	t.Run("MissingTags", func(t *testing.T) {
+		type unknownExample struct {
+			Slug  string   `binding:"required,slug"`
+			Tags  []string `binding:"omitempty,dive,eq=a|eq=b|kebab"`
+			Other string
+		}
+		m := NewMessageCatalog()
+		assert.Equal(t, []string{"kebab", "slug"}, m.MissingTags(unknownExample{}))
+
+		m.Register("slug", "{field} must be a slug")
+		m.Register("kebab", "{field} must be kebab-case")
+		assert.Equal(t, []string{}, m.MissingTags(unknownExample{}))
+	})
+	t.Run("Override", func(t *testing.T) {
+		m := NewMessageCatalog()
+		m.Register("required", "Please provide {field}")
+		e := fieldErrorFor(t, struct {
+			OldPassword string `binding:"required"`
+		}{}, "OldPassword")
+		assert.Equal(t, "Please provide Old password", m.Render(e))
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		e := fieldErrorFor(t, struct {
+			Source string `binding:"eq=google|eq=yahoo|eq=other"`
+		}{Source: "bing"}, "Source")
+		assert.Equal(t, "Source is not valid", NewMessageCatalog().Render(e))
+	})
+	t.Run("V10Tags", func(t *testing.T) {
+		type v10Example struct {
+			Color string   `binding:"oneof=red green"`
+			Tags  []string `binding:"unique"`
+		}
+		obj := v10Example{Color: "blue", Tags: []string{"a", "a"}}
+		m := NewMessageCatalog()
+		assert.Equal(t, "Color must be one of: red green", m.Render(fieldErrorFor(t, obj, "Color")))
+		assert.Equal(t, "Tags must not contain duplicate values", m.Render(fieldErrorFor(t, obj, "Tags")))
+	})
+	t.Run("BakedInTags", func(t *testing.T) {
+		for _, tag := range bakedInTags(t) {
+			_, ok := defaultTemplates[tag]
+			assert.T(t, ok, "no default template for "+tag)
+		}
+		obj := struct {
+			Slug string `binding:"startsnotwith=-,endsnotwith=-"`
+		}{Slug: "-a-"}
+		m := NewMessageCatalog()
+		assert.Equal(t, "Slug must not start with '-'", m.Render(fieldErrorFor(t, obj, "Slug")))
+	})
+	t.Run("ConditionalTags", func(t *testing.T) {
+		type conditionalExample struct {
+			Country   string
+			State     string `binding:"required_unless=Country CA"`
+			Email     string
+			Phone     string `binding:"required_without_all=Email Fax"`
+			Fax       string
+			Shipping  bool
+			AddressID string `binding:"required_with=Shipping"`
+		}
+		obj := conditionalExample{Country: "US", Shipping: true}
+		m := NewMessageCatalog()
+		assert.Equal(t, "State is required unless Country is CA", m.Render(fieldErrorFor(t, obj, "State")))
+		assert.Equal(t, "Phone is required when Email and Fax are not provided", m.Render(fieldErrorFor(t, obj, "Phone")))
+		assert.Equal(t, "Address ID is required when Shipping is provided", m.Render(fieldErrorFor(t, obj, "AddressID")))
+	})
+	t.Run("KindWording", func(t *testing.T) {
+		type kindsExample struct {
+			Code    string            `binding:"len=4"`
+			Members int               `binding:"min=1,max=8"`
+			Price   float64           `binding:"lt=10.5"`
+			Labels  map[string]string `binding:"max=2"`
+			Slots   [3]string         `binding:"max=2"`
+			Tags    []string          `binding:"eq=2"`
+		}
+		obj := kindsExample{
+			Code:    "abc",
+			Members: 9,
+			Price:   11,
+			Labels:  map[string]string{"a": "1", "b": "2", "c": "3"},
+			Slots:   [3]string{"x", "y", "z"},
+			Tags:    []string{"a"},
+		}
+		m := NewMessageCatalog()
+		assert.Equal(t, "Code must contain exactly 4 characters", m.Render(fieldErrorFor(t, obj, "Code")))
+		assert.Equal(t, "Members must be at most 8", m.Render(fieldErrorFor(t, obj, "Members")))
+		assert.Equal(t, "Price must be less than 10.5", m.Render(fieldErrorFor(t, obj, "Price")))
+		assert.Equal(t, "Labels must contain no more than 2 keys", m.Render(fieldErrorFor(t, obj, "Labels")))
+		assert.Equal(t, "Slots must contain no more than 2 entries", m.Render(fieldErrorFor(t, obj, "Slots")))
+		assert.Equal(t, "Tags must contain exactly 2 entries", m.Render(fieldErrorFor(t, obj, "Tags")))
+
+		obj.Members = 0
+		assert.Equal(t, "Members must be at least 1", m.Render(fieldErrorFor(t, obj, "Members")))
+	})
+}
+
+// bakedInTags will read the tags validator registers by default out of its
+// source, so a new one shows up here when it's upgraded.
+func bakedInTags(t *testing.T) []string {
+	fn := runtime.FuncForPC(reflect.ValueOf(validator.New).Pointer())
+	file, _ := fn.FileLine(fn.Entry())
+	f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(filepath.Dir(file), "baked_in.go"), nil, 0)
+	if err != nil {
+		t.Fatalf("reading validator's baked in tags: %v", err)
+	}
+	tags := []string{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Values) == 0 {
+			return true
+		}
+		if name := spec.Names[0].Name; name != "bakedInValidators" && name != "bakedInAliases" {
+			return true
+		}
+		for _, elt := range spec.Values[0].(*ast.CompositeLit).Elts {
+			if lit, ok := elt.(*ast.KeyValueExpr).Key.(*ast.BasicLit); ok {
+				tag, _ := strconv.Unquote(lit.Value)
+				tags = append(tags, tag)
+			}
+		}
+		return false
+	})
+	if len(tags) == 0 {
+		t.Fatal("didn't find validator's baked in tags")
+	}
+	return tags
+}
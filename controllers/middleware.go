@@ -4,47 +4,147 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"reflect"
 
 	"github.com/gin-gonic/gin"
-	"gopkg.in/go-playground/validator.v8"
+	"github.com/go-playground/validator/v10"
 )
 
 // mwParseValidation will parse the gross default error messages into
 // readable, nice messages we can display.
-func mwParseValidation() gin.HandlerFunc {
+func mwParseValidation(cfg *routerConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		body := recordBody(c.Request)
+		c.Set("pathFormat", cfg.paths)
+		c.Set("batchLimit", cfg.batchLimit)
+		c.Set("rejectUnknown", cfg.rejectUnknown)
 		c.Next()
 
 		_, exists := c.Get("controllerError")
 		if exists {
-			ret := map[string]string{}
+			loc := NegotiateLocale(c.GetHeader("Accept-Language"))
+			problems := []fieldProblem{}
+			msg := ""
+			status := http.StatusBadRequest
 			for _, e := range c.Errors {
+				if _, ok := e.Err.(*limitError); ok {
+					status = http.StatusRequestEntityTooLarge
+				}
 				switch e.Type {
 				case gin.ErrorTypeBind:
-					helpful := e.Err.(validator.ValidationErrors)
-					for _, err := range helpful {
-						ret[err.Field] = ValidationErrorToText(err)
-					}
+					problems = append(problems, fieldProblems(e.Err, body.Bytes(), loc, cfg.paths)...)
 				case gin.ErrorTypePrivate:
-					ret["msg"] = e.Error()
+					msg = e.Error()
 				default:
 					log.Println("what is this error? ", e.Error())
 				}
 			}
-			c.AbortWithStatusJSON(http.StatusBadRequest, ret)
+			if t, ok := c.Get("requestModel"); ok {
+				problems = withSources(problems, t.(reflect.Type), cfg.paths)
+			}
+			c.Header("Content-Language", loc.Locale)
+			if c.GetBool("dryRun") {
+				problems = touchedProblems(problems, c.GetHeader(ValidateFieldsHeader), cfg.paths)
+				if len(problems) == 0 && msg == "" {
+					// only untouched fields failed
+					c.AbortWithStatus(http.StatusNoContent)
+					return
+				}
+			}
+
+			mode := cfg.mode
+			if routeMode, ok := c.Get("responseMode"); ok {
+				mode = routeMode.(ResponseMode)
+			}
+			if mode == ResponseProblem {
+				c.Header("Content-Type", ProblemContentType)
+				c.AbortWithStatusJSON(status, newProblem(c, status, problems, msg))
+				return
+			}
+
+			ret := problemMap(problems)
+			if msg != "" {
+				ret["msg"] = msg
+			}
+			c.AbortWithStatusJSON(status, ret)
 			return
 		}
 	}
 }
 
+// pathFormat will return the format the router writes field paths in.
+func pathFormat(c *gin.Context) PathFormat {
+	if f, ok := c.Get("pathFormat"); ok {
+		return f.(PathFormat)
+	}
+	return DefaultPathFormat
+}
+
+// fieldProblems will describe each field that failed in a bind error.
+func fieldProblems(err error, body []byte, loc *Localizer, paths PathFormat) []fieldProblem {
+	problems := []fieldProblem{}
+	add := func(fe *fieldError, path fieldPath, paramLabels map[string]string) {
+		fp := fieldProblem{
+			Field:   path.Format(paths),
+			Tag:     fe.Tag,
+			Param:   fe.Param,
+			Message: loc.render(fe, path, paramLabels),
+			path:    path,
+		}
+		if !path.secret() && !secretTags[fe.Tag] {
+			fp.Value = fe.Value
+		}
+		problems = append(problems, fp)
+	}
+
+	switch errs := err.(type) {
+	case *modelErrors:
+		for _, e := range errs.Errors {
+			fe := newFieldError(e)
+			path := resolvePath(errs.Type, errorPath(fe))
+			labels := map[string]string{}
+			fields, _ := paramFields(fe.Tag, fe.Param)
+			for _, f := range fields {
+				labels[f] = siblingLabel(errs.Type, path, f)
+			}
+			add(fe, path, labels)
+		}
+	case *asyncErrors:
+		for _, fe := range errs.Errors {
+			add(fe, resolvePath(errs.Type, errorPath(fe)), nil)
+		}
+	case *typeError:
+		for _, fe := range bindErrorToFieldErrors(err, body) {
+			path := errorPath(fe)
+			if errs.Model != nil {
+				path = resolvePath(errs.Model, path)
+			}
+			add(fe, path, nil)
+		}
+	case validator.ValidationErrors:
+		for _, e := range errs {
+			fe := newFieldError(e)
+			add(fe, errorPath(fe), nil)
+		}
+	default:
+		// the body couldn't be bound, so nothing was validated
+		for _, fe := range bindErrorToFieldErrors(err, body) {
+			add(fe, errorPath(fe), nil)
+		}
+	}
+	return problems
+}
+
 // mwLogBody just prints out the posted body for each request
 // this helps troubleshoot failing tests - we wouldn't need this
 // in a production env.
 func mwLogBody() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		bs, _ := c.Request.GetBody()
-		bytes, _ := ioutil.ReadAll(bs)
-		log.Println("BODY: ", string(bytes))
+		if c.Request.GetBody != nil {
+			bs, _ := c.Request.GetBody()
+			bytes, _ := ioutil.ReadAll(bs)
+			log.Println("BODY: ", string(bytes))
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,299 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIPath is where GetRouter serves the spec for its routes.
+const OpenAPIPath = "/openapi.json"
+
+// OpenAPI is an OpenAPI 3 document - only the parts we fill in.
+// sauce: https://spec.openapis.org/oas/v3.0.3
+type OpenAPI struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       OpenAPIInfo                      `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+// OpenAPIInfo is the info section of the spec.
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Components holds the schemas referenced from the operations.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Operation is a single method on a path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a value passed in the path or query string.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes what can be posted to an operation.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response from an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema for a single content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// OpenAPIInfoDefault is the info GetRouter puts in its spec.
+var OpenAPIInfoDefault = OpenAPIInfo{Title: "golang-validation", Version: "1.0.0"}
+
+// schemaRef will return a schema pointing at a component.
+func schemaRef(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// buildOpenAPI will describe every route, using the response mode each
+// one has been set up with for its 400, and every batch and CSV route.
+func buildOpenAPI(cfg *routerConfig, routes []route, batchRoutes []route, csvRoutes []route) *OpenAPI {
+	spec := &OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    OpenAPIInfoDefault,
+		Paths:   map[string]map[string]*Operation{},
+		Components: Components{Schemas: map[string]*Schema{
+			"ValidationErrors": {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+			"Problem":          SchemaFor(Problem{}),
+			"BatchResult":      SchemaFor(BatchResult{}),
+			"CSVResult":        SchemaFor(CSVResult{}),
+		}},
+	}
+
+	for _, rt := range routes {
+		name := rt.modelName()
+		spec.Components.Schemas[name] = SchemaFor(rt.Model)
+
+		op := &Operation{
+			OperationID: strings.ToLower(rt.Method) + name,
+			Responses: map[string]*Response{
+				"200": {Description: "OK"},
+				"400": badRequestResponse(cfg, rt.Path),
+			},
+		}
+		switch rt.Source {
+		case FromQuery:
+			op.Parameters = schemaParameters(SchemaFor(rt.Model), "query")
+		case FromURI:
+			op.Parameters = schemaParameters(SchemaFor(rt.Model), "path")
+		case FromRequest:
+			var body *Schema
+			op.Parameters, body = requestParameters(rt.Model)
+			if body != nil {
+				op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+					gin.MIMEJSON: {Schema: body},
+				}}
+			}
+		case FromForm:
+			op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+				gin.MIMEPOSTForm:          {Schema: schemaRef(name)},
+				gin.MIMEMultipartPOSTForm: {Schema: schemaRef(name)},
+			}}
+		default:
+			op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+				gin.MIMEJSON: {Schema: schemaRef(name)},
+			}}
+		}
+
+		path := openAPIPath(rt.Path)
+		if spec.Paths[path] == nil {
+			spec.Paths[path] = map[string]*Operation{}
+		}
+		spec.Paths[path][strings.ToLower(rt.Method)] = op
+
+		if rt.patchable() {
+			spec.Components.Schemas[name+"Patch"] = partialSchema(SchemaFor(rt.Model))
+			spec.Paths[path]["patch"] = &Operation{
+				OperationID: "patch" + name,
+				RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{
+					gin.MIMEJSON: {Schema: schemaRef(name + "Patch")},
+				}},
+				Responses: op.Responses,
+			}
+		}
+	}
+
+	for _, rt := range batchRoutes {
+		name := rt.modelName()
+		spec.Components.Schemas[name] = SchemaFor(rt.Model)
+		result := map[string]MediaType{gin.MIMEJSON: {Schema: schemaRef("BatchResult")}}
+		path := openAPIPath(rt.Path + BatchSuffix)
+		if spec.Paths[path] == nil {
+			spec.Paths[path] = map[string]*Operation{}
+		}
+		spec.Paths[path][strings.ToLower(rt.Method)] = &Operation{
+			OperationID: strings.ToLower(rt.Method) + name + "Batch",
+			RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{
+				gin.MIMEJSON:           {Schema: &Schema{Type: "array", Items: schemaRef(name), MaxItems: intPtr(cfg.batchLimit)}},
+				"application/x-ndjson": {Schema: schemaRef(name)},
+			}},
+			Responses: map[string]*Response{
+				"200": {Description: "Every record is valid", Content: result},
+				"400": {Description: "Some records failed validation", Content: result},
+				"413": limitResponse(cfg, rt.Path+BatchSuffix, "records"),
+			},
+		}
+	}
+
+	for _, rt := range csvRoutes {
+		name := rt.modelName()
+		result := map[string]MediaType{gin.MIMEJSON: {Schema: schemaRef("CSVResult")}}
+		file := &Schema{Type: "string", Format: "binary"}
+		path := openAPIPath(rt.Path + CSVSuffix)
+		if spec.Paths[path] == nil {
+			spec.Paths[path] = map[string]*Operation{}
+		}
+		spec.Paths[path][strings.ToLower(rt.Method)] = &Operation{
+			OperationID: strings.ToLower(rt.Method) + name + "CSV",
+			RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{
+				gin.MIMEMultipartPOSTForm: {Schema: &Schema{
+					Type:       "object",
+					Properties: map[string]*Schema{CSVFormField: file},
+					Required:   []string{CSVFormField},
+				}},
+				MIMECSV: {Schema: file},
+			}},
+			Responses: map[string]*Response{
+				"200": {Description: "Every row is valid", Content: result},
+				"400": {Description: "Some rows failed validation", Content: result},
+				"413": limitResponse(cfg, rt.Path+CSVSuffix, "rows"),
+			},
+		}
+	}
+	return spec
+}
+
+// partialSchema will drop required from the schema and everything in it,
+// since a PATCH only sends the fields it's changing.
+func partialSchema(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	s.Required = nil
+	for _, prop := range s.Properties {
+		partialSchema(prop)
+	}
+	partialSchema(s.Items)
+	partialSchema(s.AdditionalProperties)
+	return s
+}
+
+// badRequestResponse will describe the validation errors a route writes.
+func badRequestResponse(cfg *routerConfig, path string) *Response {
+	if cfg.mode == ResponseProblem || cfg.problemRoutes[path] {
+		return &Response{Description: "Validation failed", Content: map[string]MediaType{
+			ProblemContentType: {Schema: schemaRef("Problem")},
+		}}
+	}
+	return &Response{Description: "Validation failed", Content: map[string]MediaType{
+		gin.MIMEJSON: {Schema: schemaRef("ValidationErrors")},
+	}}
+}
+
+// limitResponse will describe the error for a batch or CSV with more
+// records than the router's limit, which is written like a validation error.
+func limitResponse(cfg *routerConfig, path string, records string) *Response {
+	res := badRequestResponse(cfg, path)
+	res.Description = fmt.Sprintf("More than %d %s", cfg.batchLimit, records)
+	return res
+}
+
+// schemaParameters will turn each property of an object schema into a
+// parameter.
+func schemaParameters(s *Schema, in string) []Parameter {
+	required := map[string]bool{}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	params := []Parameter{}
+	for _, name := range sortedKeys(s.Properties) {
+		params = append(params, Parameter{
+			Name:     name,
+			In:       in,
+			Required: required[name] || in == "path",
+			Schema:   s.Properties[name],
+		})
+	}
+	return params
+}
+
+// requestParameters will describe a FromRequest model's path, query and
+// header fields as parameters, and the rest as the body's schema - nil if
+// nothing comes from the body.
+func requestParameters(model interface{}) ([]Parameter, *Schema) {
+	s := SchemaFor(model)
+	t := indirect(reflect.TypeOf(model))
+	params := []Parameter{}
+	body := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := fieldKey(f)
+		prop, ok := s.Properties[name]
+		if !ok {
+			continue
+		}
+		required := false
+		for _, r := range s.Required {
+			required = required || r == name
+		}
+		in := fieldIn(f)
+		if in == inBody {
+			body.Properties[name] = prop
+			if required {
+				body.Required = append(body.Required, name)
+			}
+			continue
+		}
+		params = append(params, Parameter{Name: name, In: in, Required: required || in == inPath, Schema: prop})
+	}
+	if len(body.Properties) == 0 {
+		return params, nil
+	}
+	return params, body
+}
+
+// openAPIPath will turn gin's :param and *param into {param}.
+func openAPIPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, ":") || strings.HasPrefix(p, "*") {
+			parts[i] = "{" + p[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// serveOpenAPI will write out the spec.
+func serveOpenAPI(spec *OpenAPI) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, spec)
+	}
+}
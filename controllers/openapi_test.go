@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestSchemaFor(t *testing.T) {
+	t.Run("lengths come from gte and lte on strings", func(t *testing.T) {
+		s := SchemaFor(models.CarExample{})
+		assert.Equal(t, "object", s.Type)
+		assert.Equal(t, []string{"Make", "Model"}, s.Required)
+		assert.Equal(t, 3, *s.Properties["Make"].MinLength)
+		assert.Equal(t, 20, *s.Properties["Make"].MaxLength)
+	})
+
+	t.Run("dive applies to the items", func(t *testing.T) {
+		artist := SchemaFor(models.AlbumExample{}).Properties["Artist"]
+		assert.Equal(t, "array", artist.Type)
+		assert.Equal(t, 1, *artist.MinItems)
+		assert.Equal(t, 5, *artist.MaxItems)
+		assert.Equal(t, 2, *artist.Items.MinLength)
+		assert.Equal(t, 50, *artist.Items.MaxLength)
+	})
+
+	t.Run("formats and enums", func(t *testing.T) {
+		lead := SchemaFor(models.LeadSourceExample{})
+		assert.Equal(t, "uuid", lead.Properties["VisitorID"].Format)
+		assert.Equal(t, []interface{}{"google", "yahoo", "other"}, lead.Properties["Source"].Enum)
+		assert.Equal(t, "email", SchemaFor(models.SignupExample{}).Properties["Email"].Format)
+	})
+
+	t.Run("numbers get a maximum and times a date-time format", func(t *testing.T) {
+		session := SchemaFor(models.StudioSessionExample{})
+		members := session.Properties["BandMembers"]
+		assert.Equal(t, "integer", members.Type)
+		assert.Equal(t, 8.0, *members.Maximum)
+		assert.Equal(t, "", members.Pattern)
+		assert.Equal(t, "date-time", session.Properties["StartTime"].Format)
+	})
+}
+
+func TestOpenAPI(t *testing.T) {
+	getSpec := func(t *testing.T, opts ...RouterOption) map[string]interface{} {
+		req := performRequest(GetRouter(opts...), "GET", OpenAPIPath, nil, nil)
+		assert.Equal(t, http.StatusOK, req.Code)
+		spec := map[string]interface{}{}
+		if err := json.Unmarshal(req.Body.Bytes(), &spec); err != nil {
+			t.Fatal(err)
+		}
+		return spec
+	}
+	badRequest := func(spec map[string]interface{}, path string) map[string]interface{} {
+		post := spec["paths"].(map[string]interface{})[path].(map[string]interface{})["post"].(map[string]interface{})
+		return post["responses"].(map[string]interface{})["400"].(map[string]interface{})["content"].(map[string]interface{})
+	}
+
+	t.Run("every route is in the spec", func(t *testing.T) {
+		spec := getSpec(t)
+		assert.Equal(t, "3.0.3", spec["openapi"])
+		paths := spec["paths"].(map[string]interface{})
+		for _, rt := range routes {
+			_, ok := paths[openAPIPath(rt.Path)]
+			assert.T(t, ok, rt.Path)
+		}
+
+		car := paths["/car"].(map[string]interface{})["post"].(map[string]interface{})
+		body := car["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+		assert.Equal(t, "#/components/schemas/CarExample", body["schema"].(map[string]interface{})["$ref"])
+
+		schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+		_, ok := schemas["CarExample"]
+		assert.T(t, ok)
+
+		_, ok = paths["/car"].(map[string]interface{})["patch"]
+		assert.T(t, ok)
+		_, ok = schemas["CarExamplePatch"].(map[string]interface{})["required"]
+		assert.T(t, !ok)
+	})
+
+	t.Run("400s follow the response mode", func(t *testing.T) {
+		_, ok := badRequest(getSpec(t), "/car")["application/json"]
+		assert.T(t, ok)
+
+		spec := getSpec(t, WithProblemDetails("/car"))
+		_, ok = badRequest(spec, "/car")[ProblemContentType]
+		assert.T(t, ok)
+		_, ok = badRequest(spec, "/album")["application/json"]
+		assert.T(t, ok)
+	})
+}
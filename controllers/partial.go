@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// crossFieldTags are the rules that compare a field with a sibling. In a
+// PATCH they're still checked for a field that wasn't sent when the
+// sibling was, ex: PasswordConfirm has to match a new Password.
+var crossFieldTags = map[string]bool{
+	"eqfield":       true,
+	"nefield":       true,
+	"gtfield":       true,
+	"gtefield":      true,
+	"ltfield":       true,
+	"ltefield":      true,
+	"fieldcontains": true,
+	"fieldexcludes": true,
+}
+
+// bindPartial will bind a PATCH's JSON body into obj and only keep the
+// errors for the fields that were sent - a field that's missing isn't
+// being changed, so required and the like don't apply to it. It returns
+// the namespaces of the fields that weren't sent.
+func bindPartial(c *gin.Context, obj interface{}) (map[string]bool, error) {
+	body, err := c.GetRawData()
+	if err != nil {
+		return nil, recordBindError(c, err)
+	}
+	err = jsonBody(c).BindBody(body, obj)
+	if _, ok := err.(*modelErrors); err != nil && !ok {
+		// the body couldn't be bound, so nothing was validated
+		return nil, recordBindError(c, err)
+	}
+
+	var doc interface{}
+	if jerr := json.Unmarshal(body, &doc); jerr != nil {
+		return nil, recordBindError(c, jerr)
+	}
+	val := reflect.Indirect(reflect.ValueOf(obj))
+	w := &partialWalk{top: val.Type().Name(), present: map[string]bool{}, absent: map[string]bool{}}
+	w.walk(val, doc, "")
+
+	if errs, ok := err.(*modelErrors); ok {
+		for _, e := range errs.Errors {
+			if w.present[newFieldError(e).Namespace] {
+				w.errs = append(w.errs, e)
+			}
+		}
+	}
+	if len(w.errs) == 0 {
+		return w.absent, nil
+	}
+	return w.absent, recordBindError(c, &modelErrors{Type: val.Type(), Errors: w.errs})
+}
+
+// partialWalk follows a PATCH's JSON document through the model, keeping
+// track of which fields were sent.
+type partialWalk struct {
+	top     string
+	present map[string]bool
+	absent  map[string]bool
+	errs    validator.ValidationErrors
+}
+
+// walk will mark each field under val that's in doc as present and each
+// one that isn't as absent, checking the cross-field rules on the absent
+// ones.
+func (w *partialWalk) walk(val reflect.Value, doc interface{}, ns string) {
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		items, _ := doc.([]interface{})
+		for i := 0; i < len(items) && i < val.Len(); i++ {
+			ins := fmt.Sprintf("%s[%d]", ns, i)
+			w.present[ins] = true
+			w.walk(val.Index(i), items[i], ins)
+		}
+	case reflect.Map:
+		entries, _ := doc.(map[string]interface{})
+		for _, k := range val.MapKeys() {
+			key := fmt.Sprint(k.Interface())
+			if entry, ok := entries[key]; ok {
+				kns := fmt.Sprintf("%s[%s]", ns, key)
+				w.present[kns] = true
+				w.walk(val.MapIndex(k), entry, kns)
+			}
+		}
+	case reflect.Struct:
+		if val.Type() == timeType {
+			return
+		}
+		sent, _ := doc.(map[string]interface{})
+		typ := val.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if f.PkgPath != "" || f.Tag.Get("json") == "-" {
+				continue
+			}
+			fns := f.Name
+			if ns != "" {
+				fns = ns + "." + f.Name
+			}
+			if sub, ok := jsonValue(sent, f); ok {
+				w.present[fns] = true
+				w.walk(val.Field(i), sub, fns)
+				continue
+			}
+			w.absent[fns] = true
+			w.checkCrossFields(val, f, fns, sent)
+		}
+	}
+}
+
+// conditionalTags are the rules that make a field required, or not
+// allowed, depending on its siblings. In a PATCH they're checked for a
+// field that wasn't sent when one of those siblings was, ex: SourceDetail
+// is required once Source is changed to other.
+var conditionalTags = map[string]bool{
+	"required_if":          true,
+	"required_unless":      true,
+	"required_with":        true,
+	"required_with_all":    true,
+	"required_without":     true,
+	"required_without_all": true,
+	"excluded_with":        true,
+	"excluded_with_all":    true,
+	"excluded_without":     true,
+	"excluded_without_all": true,
+}
+
+// checkCrossFields will check the cross-field and conditional rules of
+// the absent field f against the siblings that were sent, stopping at the
+// first that fails the same way validator does.
+func (w *partialWalk) checkCrossFields(parent reflect.Value, f reflect.StructField, ns string, sent map[string]interface{}) {
+	v := modelValidator.Engine().(*validator.Validate)
+	for _, rule := range strings.Split(f.Tag.Get(bindingTag), ",") {
+		parts := strings.SplitN(rule, "=", 2)
+		if parts[0] == "dive" {
+			return
+		}
+		if len(parts) != 2 || !(crossFieldTags[parts[0]] || conditionalTags[parts[0]]) {
+			continue
+		}
+		names, _ := paramFields(parts[0], parts[1])
+		siblings := []reflect.StructField{}
+		for _, name := range names {
+			if other, ok := parent.Type().FieldByName(name); ok {
+				siblings = append(siblings, other)
+			}
+		}
+		if len(siblings) == 0 || !anySent(sent, siblings) {
+			continue
+		}
+
+		var err error
+		if conditionalTags[parts[0]] {
+			err = checkConditional(v, parent, f, siblings, rule)
+		} else {
+			err = v.VarWithValue(parent.FieldByIndex(f.Index).Interface(), parent.FieldByIndex(siblings[0].Index).Interface(), parts[0])
+		}
+		if errs, ok := err.(validator.ValidationErrors); ok && len(errs) > 0 {
+			w.errs = append(w.errs, &crossFieldError{
+				FieldError: errs[0],
+				namespace:  w.top + "." + ns,
+				field:      f.Name,
+				param:      parts[1],
+			})
+			return
+		}
+	}
+}
+
+// anySent will report whether any of the fields were in the body.
+func anySent(sent map[string]interface{}, fields []reflect.StructField) bool {
+	for _, f := range fields {
+		if _, ok := jsonValue(sent, f); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkConditional will check a conditional rule on f. Those rules look
+// the siblings up on f's struct, which VarWithValue doesn't have, so it's
+// checked on a struct of just f - with only that rule - and the siblings.
+func checkConditional(v *validator.Validate, parent reflect.Value, f reflect.StructField, siblings []reflect.StructField, rule string) error {
+	fields := []reflect.StructField{{Name: f.Name, Type: f.Type, Tag: reflect.StructTag(bindingTag + `:"` + rule + `"`)}}
+	for _, s := range siblings {
+		if s.Name != f.Name {
+			fields = append(fields, reflect.StructField{Name: s.Name, Type: s.Type})
+		}
+	}
+	val := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, sf := range fields {
+		val.Field(i).Set(parent.FieldByName(sf.Name))
+	}
+	return v.Struct(val.Interface())
+}
+
+// jsonValue will return the value sent for the field, matching the key
+// the way encoding/json does - exactly, then ignoring case.
+func jsonValue(sent map[string]interface{}, f reflect.StructField) (interface{}, bool) {
+	key := f.Name
+	if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "" {
+		key = name
+	}
+	if v, ok := sent[key]; ok {
+		return v, true
+	}
+	for k, v := range sent {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// crossFieldError is a cross-field rule checked on its own with
+// VarWithValue, which doesn't know where the field is or what the rule's
+// param was, so they're filled in here.
+type crossFieldError struct {
+	validator.FieldError
+	namespace string
+	field     string
+	param     string
+}
+
+func (e *crossFieldError) Namespace() string       { return e.namespace }
+func (e *crossFieldError) StructNamespace() string { return e.namespace }
+func (e *crossFieldError) Field() string           { return e.field }
+func (e *crossFieldError) StructField() string     { return e.field }
+func (e *crossFieldError) Param() string           { return e.param }
+
+func (e *crossFieldError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the '%s' tag", e.namespace, e.field, e.Tag())
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestPatch(t *testing.T) {
+	t.Run("PatchTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:    "only-some-fields-sent",
+				Path:    "/car",
+				Method:  "PATCH",
+				ExpCode: 200,
+				RawBody: `{"Make": "test make"}`,
+			},
+			testCase{
+				Name:        "sent-field-invalid",
+				Path:        "/car",
+				Method:      "PATCH",
+				ExpCode:     400,
+				RawBody:     `{"Make": "aa"}`,
+				ExpFields:   []string{"Make"},
+				ExpMessages: []string{"Make must contain at least 3 characters"},
+			},
+			testCase{
+				Name:        "sent-field-empty",
+				Path:        "/car",
+				Method:      "PATCH",
+				ExpCode:     400,
+				RawBody:     `{"Make": ""}`,
+				ExpFields:   []string{"Make"},
+				ExpMessages: []string{"Make is required"},
+			},
+			testCase{
+				Name:        "not-an-object",
+				Path:        "/car",
+				Method:      "PATCH",
+				ExpCode:     400,
+				RawBody:     `[{"Make": "test make"}]`,
+				ExpFields:   []string{"body"},
+				ExpMessages: []string{"Request body must be a JSON object"},
+			},
+			testCase{
+				Name:        "sent-entry-invalid",
+				Path:        "/album",
+				Method:      "PATCH",
+				ExpCode:     400,
+				RawBody:     `{"Artist": ["a"]}`,
+				ExpFields:   []string{"Artist[0]"},
+				ExpMessages: []string{"Artist entry 1 must contain at least 2 characters"},
+			},
+			testCase{
+				Name:        "password-without-confirm",
+				Path:        "/password",
+				Method:      "PATCH",
+				ExpCode:     400,
+				RawBody:     `{"Password": "Tr0ub4dor&3"}`,
+				ExpFields:   []string{"PasswordConfirm"},
+				ExpMessages: []string{"Password confirm must match Password"},
+			},
+			testCase{
+				Name:        "confirm-without-password",
+				Path:        "/password",
+				Method:      "PATCH",
+				ExpCode:     400,
+				RawBody:     `{"PasswordConfirm": "Tr0ub4dor&3"}`,
+				ExpFields:   []string{"PasswordConfirm"},
+				ExpMessages: []string{"Password confirm must match Password"},
+			},
+			testCase{
+				Name:        "password-same-as-old",
+				Path:        "/password",
+				Method:      "PATCH",
+				ExpCode:     400,
+				RawBody:     `{"OldPassword": "Tr0ub4dor&3", "Password": "Tr0ub4dor&3", "PasswordConfirm": "Tr0ub4dor&3"}`,
+				ExpFields:   []string{"Password"},
+				ExpMessages: []string{"Password must not be the same as Old password"},
+			},
+			testCase{
+				Name:    "password-and-confirm",
+				Path:    "/password",
+				Method:  "PATCH",
+				ExpCode: 200,
+				RawBody: `{"Password": "Tr0ub4dor&3", "PasswordConfirm": "Tr0ub4dor&3"}`,
+			},
+			testCase{
+				Name:        "source-changed-to-other",
+				Path:        "/lead",
+				Method:      "PATCH",
+				ExpCode:     400,
+				RawBody:     `{"Source": "other"}`,
+				ExpFields:   []string{"SourceDetail"},
+				ExpMessages: []string{"Source detail is required when Source is other"},
+			},
+			testCase{
+				Name:    "source-changed-to-google",
+				Path:    "/lead",
+				Method:  "PATCH",
+				ExpCode: 200,
+				RawBody: `{"Source": "google"}`,
+			},
+			testCase{
+				Name:    "source-and-detail",
+				Path:    "/lead",
+				Method:  "PATCH",
+				ExpCode: 200,
+				RawBody: `{"Source": "other", "SourceDetail": "a friend"}`,
+			},
+			testCase{
+				Name:      "malformed-body",
+				Path:      "/car",
+				Method:    "PATCH",
+				ExpCode:   400,
+				RawBody:   `{"Make":`,
+				ExpFields: []string{"body"},
+			},
+		}
+
+		runTests(t, tests, GetRouter())
+	})
+
+	t.Run("missing fields are left out", func(t *testing.T) {
+		body := []byte(`{"Make": "aa"}`)
+		req := performRequest(GetRouter(), "PATCH", "/car", &body, map[string]string{"Content-Type": "application/json"})
+		assert.Equal(t, http.StatusBadRequest, req.Code)
+		errs := map[string]string{}
+		json.Unmarshal(req.Body.Bytes(), &errs)
+		assert.Equal(t, map[string]string{"Make": "Make must contain at least 3 characters"}, errs)
+	})
+}
@@ -0,0 +1,6 @@
+package controllers
+
+import "github.com/mike-webster/golang-validation/models"
+
+// partnershipRequestHandler will handle POST requests to /partnership-request
+var partnershipRequestHandler = BindHandler(FromBody, respondOK[models.PartnershipRequestExample])
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestPostPartnershipRequest(t *testing.T) {
+	t.Run("PartnershipRequestTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "nothing-provided",
+				Path:        "/partnership-request",
+				ExpCode:     400,
+				ExpFields:   []string{"CompanyName", "Website"},
+				ExpMessages: []string{"Company name is required", "Website is required"},
+				Body:        models.PartnershipRequestExample{},
+			},
+			testCase{
+				Name:        "website-not-url",
+				Path:        "/partnership-request",
+				ExpCode:     400,
+				ExpFields:   []string{"Website"},
+				ExpMessages: []string{"Website must be a valid url"},
+				Body: models.PartnershipRequestExample{
+					CompanyName: "Acme",
+					Website:     "acme dot com",
+				},
+			},
+			testCase{
+				Name:        "referrer-not-uri",
+				Path:        "/partnership-request",
+				ExpCode:     400,
+				ExpFields:   []string{"Referrer"},
+				ExpMessages: []string{"Referrer must be a valid uri"},
+				Body: models.PartnershipRequestExample{
+					CompanyName: "Acme",
+					Website:     "https://acme.example.com",
+					Referrer:    "not a uri",
+				},
+			},
+			testCase{
+				Name:    "success-without-referrer",
+				Path:    "/partnership-request",
+				ExpCode: 200,
+				Body: models.PartnershipRequestExample{
+					CompanyName: "Acme",
+					Website:     "https://acme.example.com",
+				},
+			},
+			testCase{
+				Name:    "success",
+				Path:    "/partnership-request",
+				ExpCode: 200,
+				Body: models.PartnershipRequestExample{
+					CompanyName: "Acme",
+					Website:     "https://acme.example.com",
+					Referrer:    "https://news.example.com/partners",
+				},
+			},
+		}
+		runTests(t, tests, GetRouter())
+	})
+}
@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// CommonPasswordWords are worth next to nothing when they show up in a
+// password, even with l33t substitutions (ex: P@ssw0rd).
+var CommonPasswordWords = []string{
+	"password", "qwerty", "letmein", "welcome", "admin", "dragon", "monkey",
+	"football", "baseball", "iloveyou", "sunshine", "master", "login",
+	"princess", "shadow", "trustno1", "abc123", "123456",
+}
+
+var leetReplacer = strings.NewReplacer(
+	"0", "o", "1", "l", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s", "!", "i",
+)
+
+// Breached is the list the notbreached tag checks against. It's empty
+// until a list is loaded, ex: Breached.LoadFile("pwned-passwords.txt")
+var Breached = NewBreachedPasswords()
+
+func init() {
+	tags := []struct {
+		tag      string
+		fn       validator.Func
+		template string
+	}{
+		{"minentropy", hasMinEntropy, "{field} is too easy to guess"},
+		{"charclasses", hasCharClasses, "{field} must use at least {param} of: lowercase letters, uppercase letters, numbers and symbols"},
+		{"notcontainsfield", notContainsField, "{field} must not contain {paramField}"},
+		{"notbreached", isNotBreached, "{field} has appeared in a data breach, please choose another"},
+	}
+	for _, t := range tags {
+		if err := RegisterValidation(t.tag, t.fn, t.template); err != nil {
+			panic(err)
+		}
+	}
+
+	paramChecks["minentropy"] = func(param string) error {
+		_, err := parseMinEntropy(param)
+		return err
+	}
+	paramChecks["charclasses"] = func(param string) error {
+		_, err := parseCharClasses(param)
+		return err
+	}
+}
+
+// PasswordEntropy will estimate how many bits of entropy a password has.
+// Like zxcvbn it gives credit for the size of the character pool being
+// used, but very little for repeated characters, runs (abc, 321) and
+// common words.
+func PasswordEntropy(password string) float64 {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+	perChar := math.Log2(float64(charPool(password)))
+
+	// common words are worth about as much as picking one from the list
+	lower := leetReplacer.Replace(strings.ToLower(password))
+	covered := make([]bool, len(runes))
+	words := 0
+	for _, w := range CommonPasswordWords {
+		if i := strings.Index(lower, w); i >= 0 {
+			start := len([]rune(lower[:i]))
+			for j := start; j < start+len([]rune(w)) && j < len(covered); j++ {
+				covered[j] = true
+			}
+			words++
+		}
+	}
+	bits := float64(words) * math.Log2(float64(len(CommonPasswordWords)))
+
+	for i, r := range runes {
+		if covered[i] {
+			continue
+		}
+		if i > 0 {
+			diff := r - runes[i-1]
+			if diff == 0 || diff == 1 || diff == -1 {
+				// repeats and runs are easy to guess
+				bits++
+				continue
+			}
+		}
+		bits += perChar
+	}
+	return bits
+}
+
+// charPool will return the number of characters an attacker would have
+// to try per position, given the classes used in the password.
+func charPool(password string) int {
+	pool := 0
+	classes := charClasses(password)
+	for class, size := range map[string]int{"lower": 26, "upper": 26, "number": 10, "symbol": 33, "other": 100} {
+		if classes[class] {
+			pool += size
+		}
+	}
+	return pool
+}
+
+// charClasses will return which classes of character the string uses.
+func charClasses(s string) map[string]bool {
+	classes := map[string]bool{}
+	for _, r := range s {
+		switch {
+		case r > unicode.MaxASCII:
+			classes["other"] = true
+		case unicode.IsLower(r):
+			classes["lower"] = true
+		case unicode.IsUpper(r):
+			classes["upper"] = true
+		case unicode.IsDigit(r):
+			classes["number"] = true
+		default:
+			classes["symbol"] = true
+		}
+	}
+	return classes
+}
+
+// hasMinEntropy will check the field's PasswordEntropy is at least param bits.
+func hasMinEntropy(fl validator.FieldLevel) bool {
+	min, err := parseMinEntropy(fl.Param())
+	return err == nil && PasswordEntropy(fl.Field().String()) >= min
+}
+
+// parseMinEntropy will read a minentropy param, ex: 50
+func parseMinEntropy(param string) (float64, error) {
+	min, err := strconv.ParseFloat(param, 64)
+	if err != nil || min < 0 {
+		return 0, fmt.Errorf("minentropy: bad param %q", param)
+	}
+	return min, nil
+}
+
+// hasCharClasses will check the field uses at least param of lowercase,
+// uppercase, numbers and symbols.
+func hasCharClasses(fl validator.FieldLevel) bool {
+	min, err := parseCharClasses(fl.Param())
+	if err != nil {
+		return false
+	}
+	classes := charClasses(fl.Field().String())
+	if classes["other"] {
+		// letters outside of ascii count as symbols
+		classes["symbol"] = true
+	}
+	delete(classes, "other")
+	return len(classes) >= min
+}
+
+// parseCharClasses will read a charclasses param, which is how many of the
+// 4 classes have to be used.
+func parseCharClasses(param string) (int, error) {
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 0 || n > 4 {
+		return 0, fmt.Errorf("charclasses: bad param %q", param)
+	}
+	return n, nil
+}
+
+// notContainsField will check the field doesn't contain the value of the
+// field named in param, ignoring case (ex: a password with the username in it).
+func notContainsField(fl validator.FieldLevel) bool {
+	other := fl.Parent()
+	for other.Kind() == reflect.Ptr {
+		other = other.Elem()
+	}
+	if other.Kind() != reflect.Struct {
+		return true
+	}
+	value := other.FieldByName(fl.Param())
+	if !value.IsValid() || value.Kind() != reflect.String || value.String() == "" {
+		return true
+	}
+	return !strings.Contains(strings.ToLower(fl.Field().String()), strings.ToLower(value.String()))
+}
+
+// isNotBreached will check the field isn't in the Breached list.
+func isNotBreached(fl validator.FieldLevel) bool {
+	return !Breached.Contains(fl.Field().String())
+}
+
+// BreachedPasswords is a list of SHA-1 hashes of breached passwords,
+// grouped by the first 5 characters of the hash - the same k-anonymity
+// scheme as the haveibeenpwned range API, so a list downloaded from
+// there can be loaded as-is.
+type BreachedPasswords struct {
+	mu     sync.RWMutex
+	ranges map[string]map[string]int
+}
+
+// NewBreachedPasswords will return an empty list.
+func NewBreachedPasswords() *BreachedPasswords {
+	return &BreachedPasswords{ranges: map[string]map[string]int{}}
+}
+
+// LoadFile will add the hashes in the given file to the list.
+func (b *BreachedPasswords) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return b.Load(f)
+}
+
+// Load will add hashes to the list, one per line, as either HASH or
+// HASH:COUNT (ex: 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493).
+func (b *BreachedPasswords) Load(r io.Reader) error {
+	return b.load("", r)
+}
+
+// LoadRange will add the hashes from a range file - the suffixes of every
+// hash starting with the prefix, one SUFFIX:COUNT per line, the same as
+// a response from https://api.pwnedpasswords.com/range/{prefix}
+func (b *BreachedPasswords) LoadRange(prefix string, r io.Reader) error {
+	if len(prefix) != 5 {
+		return fmt.Errorf("prefix must be 5 characters")
+	}
+	return b.load(strings.ToUpper(prefix), r)
+}
+
+func (b *BreachedPasswords) load(prefix string, r io.Reader) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		parts := strings.SplitN(text, ":", 2)
+		hash := prefix + strings.ToUpper(parts[0])
+		if len(hash) != sha1.Size*2 {
+			return fmt.Errorf("line %d: not a sha1 hash", line)
+		}
+		count := 1
+		if len(parts) == 2 {
+			count, _ = strconv.Atoi(parts[1])
+		}
+		if b.ranges[hash[:5]] == nil {
+			b.ranges[hash[:5]] = map[string]int{}
+		}
+		b.ranges[hash[:5]][hash[5:]] = count
+	}
+	return scanner.Err()
+}
+
+// Range will return the suffixes, and how often they've been seen, of
+// every hash starting with the given 5 character prefix.
+func (b *BreachedPasswords) Range(prefix string) map[string]int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	ret := map[string]int{}
+	for suffix, count := range b.ranges[strings.ToUpper(prefix)] {
+		ret[suffix] = count
+	}
+	return ret
+}
+
+// Contains will check if the password is in the list.
+func (b *BreachedPasswords) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.ranges[hash[:5]][hash[5:]]
+	return ok
+}
@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/bmizerany/assert"
 	"github.com/mike-webster/golang-validation/models"
 )
 
@@ -243,3 +245,90 @@ func TestPostPassword(t *testing.T) {
 		runTests(t, tests, GetRouter())
 	})
 }
+
+func TestPasswordPolicy(t *testing.T) {
+	// sha1 of C0rrect-Horse!, split the way a range file is
+	err := Breached.LoadRange("28179", strings.NewReader("A72C927714C3CD028EF88C0A10FB88804A3:42\n"))
+	assert.Equal(t, nil, err)
+
+	t.Run("PasswordPolicyTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "password-too-few-character-classes",
+				Path:        "/password",
+				ExpCode:     400,
+				ExpFields:   []string{"Password"},
+				ExpMessages: []string{"Password must use at least 3 of: lowercase letters, uppercase letters, numbers and symbols"},
+				Body: models.PasswordExample{
+					Username:        "fdsafdfdsfds",
+					Password:        "abcdefghij",
+					PasswordConfirm: "abcdefghij",
+					OldPassword:     "oldtestpass",
+				},
+			},
+			testCase{
+				Name:        "password-contains-username",
+				Path:        "/password",
+				ExpCode:     400,
+				ExpFields:   []string{"Password"},
+				ExpMessages: []string{"Password must not contain Username"},
+				Body: models.PasswordExample{
+					Username:        "fdsafdfdsfds",
+					Password:        "FdsaFdfdsfds9!",
+					PasswordConfirm: "FdsaFdfdsfds9!",
+					OldPassword:     "oldtestpass",
+				},
+			},
+			testCase{
+				Name:        "password-too-easy-to-guess",
+				Path:        "/password",
+				ExpCode:     400,
+				ExpFields:   []string{"Password"},
+				ExpMessages: []string{"Password is too easy to guess"},
+				Body: models.PasswordExample{
+					Username:        "fdsafdfdsfds",
+					Password:        "Aaaaaaaa1!",
+					PasswordConfirm: "Aaaaaaaa1!",
+					OldPassword:     "oldtestpass",
+				},
+			},
+			testCase{
+				Name:        "password-breached",
+				Path:        "/password",
+				ExpCode:     400,
+				ExpFields:   []string{"Password"},
+				ExpMessages: []string{"Password has appeared in a data breach, please choose another"},
+				Body: models.PasswordExample{
+					Username:        "fdsafdfdsfds",
+					Password:        "C0rrect-Horse!",
+					PasswordConfirm: "C0rrect-Horse!",
+					OldPassword:     "oldtestpass",
+				},
+			},
+			testCase{
+				Name:    "success",
+				Path:    "/password",
+				ExpCode: 200,
+				Body: models.PasswordExample{
+					Username:        "fdsafdfdsfds",
+					Password:        "Tr0ub4dor&3",
+					PasswordConfirm: "Tr0ub4dor&3",
+					OldPassword:     "oldtestpass",
+				},
+			},
+		}
+		runTests(t, tests, GetRouter())
+	})
+}
+
+func TestPasswordBadParams(t *testing.T) {
+	type badPassword struct {
+		Password string `binding:"minentropy=lots,charclasses=5"`
+		Pin      string `binding:"charclasses=two"`
+	}
+	assert.Equal(t, []string{
+		`charclasses: bad param "5"`,
+		`charclasses: bad param "two"`,
+		`minentropy: bad param "lots"`,
+	}, BadParams(badPassword{}))
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"strings"
+)
+
+// PathFormat decides how the path to a field is written in response keys.
+type PathFormat struct {
+	// Separator goes between nested fields, ex: Address.Street
+	Separator string
+	// JSONPointer writes paths as RFC 6901 pointers instead, ex: /Artist/2
+	JSONPointer bool
+}
+
+// DefaultPathFormat writes paths like Items[2].Name
+var DefaultPathFormat = PathFormat{Separator: "."}
+
+// pathSegment is one step in a field path - either a field name or the
+// index/key of an element in a slice, array or map.
+type pathSegment struct {
+	Name    string
+	Index   string
+	IsIndex bool
+	// IsKey is set when the index is a map key rather than a position
+	IsKey bool
+	// Key is the name the client knows the field by, when it's been resolved
+	Key string
+	// Label is the display name from the field's label tag, if it has one
+	Label string
+	// Secret is set for a field whose value shouldn't be echoed back
+	Secret bool
+}
+
+// key will return the name to use for this segment in response keys.
+func (s pathSegment) key() string {
+	if s.Key != "" {
+		return s.Key
+	}
+	return s.Name
+}
+
+// fieldPath is the path from the top level struct down to a field.
+type fieldPath []pathSegment
+
+// errorPath will return the path to the field that failed, relative to
+// the struct that was validated.
+func errorPath(e *fieldError) fieldPath {
+	return parsePath(e.Namespace)
+}
+
+// parsePath will turn a namespace like Items[2].Name into its segments.
+func parsePath(ns string) fieldPath {
+	path := fieldPath{}
+	for _, part := range strings.Split(ns, ".") {
+		for part != "" {
+			open := strings.Index(part, "[")
+			if open < 0 {
+				path = append(path, pathSegment{Name: part})
+				break
+			}
+			if open > 0 {
+				path = append(path, pathSegment{Name: part[:open]})
+			}
+			close := strings.Index(part, "]")
+			if close < open {
+				// not an index we understand, keep it as written
+				path = append(path, pathSegment{Name: part[open:]})
+				break
+			}
+			path = append(path, pathSegment{Index: part[open+1 : close], IsIndex: true})
+			part = part[close+1:]
+		}
+	}
+	return path
+}
+
+// Format will write the path out using the given format.
+func (p fieldPath) Format(f PathFormat) string {
+	if f.JSONPointer {
+		escape := strings.NewReplacer("~", "~0", "/", "~1")
+		b := strings.Builder{}
+		for _, s := range p {
+			b.WriteString("/")
+			if s.IsIndex {
+				b.WriteString(escape.Replace(s.Index))
+			} else {
+				b.WriteString(escape.Replace(s.key()))
+			}
+		}
+		return b.String()
+	}
+
+	sep := f.Separator
+	if sep == "" {
+		sep = DefaultPathFormat.Separator
+	}
+	b := strings.Builder{}
+	for i, s := range p {
+		switch {
+		case s.IsIndex:
+			b.WriteString("[" + s.Index + "]")
+		case i > 0:
+			b.WriteString(sep + s.key())
+		default:
+			b.WriteString(s.key())
+		}
+	}
+	return b.String()
+}
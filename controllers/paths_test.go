@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/go-playground/validator/v10"
+)
+
+type pathsAddress struct {
+	Street string `binding:"required"`
+}
+
+type pathsItem struct {
+	Name string `binding:"required"`
+}
+
+type pathsExample struct {
+	Name    string `binding:"required"`
+	Address pathsAddress
+	Items   []pathsItem       `binding:"dive"`
+	Artist  []string          `binding:"dive,gte=2"`
+	Tags    map[string]string `binding:"dive,required"`
+}
+
+func TestFieldPaths(t *testing.T) {
+	v := modelValidator.Engine().(*validator.Validate)
+	err := v.Struct(pathsExample{
+		Items:  []pathsItem{{Name: "ok"}, {}, {}},
+		Artist: []string{"ok", "ok", "x"},
+		Tags:   map[string]string{"color": ""},
+	})
+	errs := map[string]validator.FieldError{}
+	for _, e := range err.(validator.ValidationErrors) {
+		errs[e.StructNamespace()] = e
+	}
+
+	tests := []struct {
+		Namespace string
+		Default   string
+		Custom    string
+		Pointer   string
+		Message   string
+	}{
+		{"pathsExample.Name", "Name", "Name", "/Name", "Name is required"},
+		{"pathsExample.Address.Street", "Address.Street", "Address/Street", "/Address/Street", "Address street is required"},
+		{"pathsExample.Items[2].Name", "Items[2].Name", "Items[2]/Name", "/Items/2/Name", "Items entry 3 name is required"},
+		{"pathsExample.Artist[2]", "Artist[2]", "Artist[2]", "/Artist/2", "Artist entry 3 must contain at least 2 characters"},
+		{"pathsExample.Tags[color]", "Tags[color]", "Tags[color]", "/Tags/color", "Tags 'color' is required"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.Namespace, func(t *testing.T) {
+			e, ok := errs[tc.Namespace]
+			assert.Equal(t, true, ok, errs)
+
+			p := errorPath(newFieldError(e))
+			assert.Equal(t, tc.Default, p.Format(DefaultPathFormat))
+			assert.Equal(t, tc.Custom, p.Format(PathFormat{Separator: "/"}))
+			assert.Equal(t, tc.Pointer, p.Format(PathFormat{JSONPointer: true}))
+			assert.Equal(t, tc.Message, ValidationErrorToText(e))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseMode decides how mwParseValidation writes validation errors.
+type ResponseMode int
+
+const (
+	// ResponseMap writes a flat {"Field": "message"} object - the default.
+	ResponseMap ResponseMode = iota
+	// ResponseProblem writes an RFC 7807 application/problem+json document.
+	ResponseProblem
+)
+
+// ProblemContentType is the media type used for problem details.
+const ProblemContentType = "application/problem+json"
+
+// ProblemType is the "type" member of every validation problem. Point it at
+// your own docs if you have a page describing validation errors.
+var ProblemType = "about:blank"
+
+// Problem is an RFC 7807 problem details document.
+// sauce: https://tools.ietf.org/html/rfc7807
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+}
+
+// InvalidParam describes a single field that failed validation.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Tag    string `json:"tag"`
+	Param  string `json:"param,omitempty"`
+	Reason string `json:"reason"`
+	Code   string `json:"code"`
+	// Value is what was sent for the field, left out for secrets like passwords
+	Value interface{} `json:"value,omitempty"`
+}
+
+// fieldProblem is a single validation failure, before it's been written
+// out in whichever response mode the route uses.
+type fieldProblem struct {
+	Field   string
+	Tag     string
+	Param   string
+	Message string
+	// Value is what was sent, or nil if it can't be sent back
+	Value interface{}
+	// path is what Field was written from
+	path fieldPath
+}
+
+// ProblemDetails will switch the routes it's used on to problem+json
+// responses, ex: r.POST("/car", ProblemDetails(), carHandler)
+func ProblemDetails() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("responseMode", ResponseProblem)
+		c.Next()
+	}
+}
+
+// problemCode will return the machine-readable code for a tag, which is
+// the same for every locale.
+func problemCode(tag string) string {
+	if tag == "" || strings.Contains(tag, "|") {
+		return "validation.invalid"
+	}
+	return "validation." + tag
+}
+
+// newProblem will build the problem document for the given failures.
+func newProblem(c *gin.Context, status int, problems []fieldProblem, detail string) Problem {
+	p := Problem{
+		Type:          ProblemType,
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        detail,
+		Instance:      c.Request.URL.Path,
+		InvalidParams: []InvalidParam{},
+	}
+	for _, fp := range problems {
+		p.InvalidParams = append(p.InvalidParams, InvalidParam{
+			Name:   fp.Field,
+			Tag:    fp.Tag,
+			Param:  fp.Param,
+			Reason: fp.Message,
+			Code:   problemCode(fp.Tag),
+			Value:  fp.Value,
+		})
+	}
+	if p.Detail == "" {
+		p.Detail = fmt.Sprintf("%d field(s) failed validation", len(problems))
+	}
+	return p
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestProblemDetails(t *testing.T) {
+	headers := map[string]string{"Content-Type": "application/json"}
+	body, _ := json.Marshal(models.CarExample{Make: "aa"})
+
+	t.Run("Global", func(t *testing.T) {
+		req := performRequest(GetRouter(WithProblemDetails()), "POST", "/car", &body, headers)
+		assert.Equal(t, 400, req.Code)
+		assert.Equal(t, ProblemContentType, req.Header().Get("Content-Type"))
+
+		var p Problem
+		_ = json.Unmarshal(req.Body.Bytes(), &p)
+		assert.Equal(t, 400, p.Status)
+		assert.Equal(t, "/car", p.Instance)
+		assert.Equal(t, 2, len(p.InvalidParams))
+
+		params := map[string]InvalidParam{}
+		for _, ip := range p.InvalidParams {
+			params[ip.Name] = ip
+		}
+		assert.Equal(t, InvalidParam{
+			Name:   "Make",
+			Tag:    "gte",
+			Param:  "3",
+			Reason: "Make must contain at least 3 characters",
+			Code:   "validation.gte",
+			Value:  "aa",
+		}, params["Make"])
+		assert.Equal(t, "validation.required", params["Model"].Code)
+		assert.Equal(t, "", params["Model"].Value)
+	})
+	t.Run("SecretsLeftOut", func(t *testing.T) {
+		body, _ := json.Marshal(models.PasswordExample{
+			Username:        "bad name!",
+			OldPassword:     "short",
+			Password:        "password1",
+			PasswordConfirm: "password2",
+		})
+		req := performRequest(GetRouter(WithProblemDetails()), "POST", "/password", &body, headers)
+		var p Problem
+		_ = json.Unmarshal(req.Body.Bytes(), &p)
+
+		values := map[string]interface{}{}
+		for _, ip := range p.InvalidParams {
+			values[ip.Name] = ip.Value
+		}
+		assert.Equal(t, map[string]interface{}{
+			"Username":        "bad name!",
+			"OldPassword":     nil,
+			"Password":        nil,
+			"PasswordConfirm": nil,
+		}, values)
+	})
+	t.Run("PerRoute", func(t *testing.T) {
+		r := GetRouter(WithProblemDetails("/album"))
+
+		req := performRequest(r, "POST", "/car", &body, headers)
+		assert.Equal(t, "application/json; charset=utf-8", req.Header().Get("Content-Type"))
+
+		album, _ := json.Marshal(models.AlbumExample{Name: "dude ranch"})
+		req = performRequest(r, "POST", "/album", &album, headers)
+		assert.Equal(t, ProblemContentType, req.Header().Get("Content-Type"))
+	})
+	t.Run("BatchAndCSVRoutes", func(t *testing.T) {
+		r := GetRouter(WithProblemDetails("/lead/batch", "/lead/csv"))
+		empty := []byte{}
+
+		req := performRequest(r, "POST", "/lead/batch", &empty, headers)
+		assert.Equal(t, ProblemContentType, req.Header().Get("Content-Type"))
+
+		req = performRequest(r, "POST", "/lead/csv", &empty, map[string]string{"Content-Type": MIMECSV})
+		assert.Equal(t, ProblemContentType, req.Header().Get("Content-Type"))
+	})
+}
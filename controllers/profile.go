@@ -0,0 +1,6 @@
+package controllers
+
+import "github.com/mike-webster/golang-validation/models"
+
+// profileHandler will handle multipart POST requests to /profile
+var profileHandler = BindHandler(FromForm, respondOK[models.ProfileUploadExample])
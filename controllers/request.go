@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// headerTag names the header a field is bound from, ex: `header:"X-Request-ID"`
+const headerTag = "header"
+
+// The parts of a request a FromRequest field can come from. These double
+// as the prefix on its error keys (ex: query.limit) and as its "in" in
+// the OpenAPI spec.
+const (
+	inPath   = "path"
+	inQuery  = "query"
+	inHeader = "header"
+	inBody   = "body"
+)
+
+// fieldIn will return the part of the request a field is bound from by
+// FromRequest - its uri, header or form tag, otherwise the JSON body.
+func fieldIn(f reflect.StructField) string {
+	switch {
+	case f.Tag.Get(uriTag) != "":
+		return inPath
+	case f.Tag.Get(headerTag) != "":
+		return inHeader
+	case f.Tag.Get("form") != "":
+		return inQuery
+	}
+	return inBody
+}
+
+// bindRequest will bind each of obj's fields from the part of the request
+// its tags say it comes from, then validate the lot together.
+func bindRequest(c *gin.Context, obj interface{}) error {
+	val := reflect.ValueOf(obj).Elem()
+	typ := val.Type()
+
+	if c.Request.Body != nil && c.Request.ContentLength != 0 {
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			return err
+		}
+		if err := decodeJSON(body, obj, c.GetBool("rejectUnknown")); err != nil && err != io.EOF {
+			return err
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			if fieldIn(typ.Field(i)) != inBody && val.Field(i).CanSet() {
+				// only path, query and header values count for these
+				val.Field(i).Set(reflect.Zero(typ.Field(i).Type))
+			}
+		}
+	}
+
+	query := c.Request.URL.Query()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !val.Field(i).CanSet() {
+			continue
+		}
+		var values []string
+		switch fieldIn(f) {
+		case inPath:
+			if value, ok := c.Params.Get(f.Tag.Get(uriTag)); ok {
+				values = []string{value}
+			}
+		case inHeader:
+			values = c.Request.Header.Values(f.Tag.Get(headerTag))
+		case inQuery:
+			values = query[strings.Split(f.Tag.Get("form"), ",")[0]]
+		}
+		if err := setValues(val.Field(i), values); err != nil {
+			return &typeError{Field: f.Name, Type: f.Type, Model: typ}
+		}
+	}
+
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// setValues will set the field from the values given for it - every one
+// of them for a slice, otherwise the first.
+func setValues(field reflect.Value, values []string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if field.Kind() != reflect.Slice {
+		return setField(field, values[0])
+	}
+	s := reflect.MakeSlice(field.Type(), len(values), len(values))
+	for i, v := range values {
+		if err := setField(s.Index(i), v); err != nil {
+			return err
+		}
+	}
+	field.Set(s)
+	return nil
+}
+
+// withSources will put the part of the request each problem's field came
+// from in front of its key, ex: limit -> query.limit. Errors about the
+// body as a whole are already keyed "body" and are left alone.
+func withSources(problems []fieldProblem, t reflect.Type, paths PathFormat) []fieldProblem {
+	for i, fp := range problems {
+		if len(fp.path) == 0 || fp.Field == bodyField {
+			continue
+		}
+		path := resolvePath(t, fp.path)
+		in := inBody
+		if f, ok := indirect(t).FieldByName(path[0].Name); ok && !path[0].IsIndex {
+			in = fieldIn(f)
+		}
+		problems[i].Field = append(fieldPath{{Name: in}}, path...).Format(paths)
+	}
+	return problems
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func TestRequestSources(t *testing.T) {
+	t.Run("RequestTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:    "valid-query",
+				Method:  http.MethodGet,
+				Path:    "/cars?limit=20&offset=40&sort=-year",
+				ExpCode: 200,
+			},
+			testCase{
+				Name:    "no-query",
+				Method:  http.MethodGet,
+				Path:    "/cars",
+				ExpCode: 200,
+			},
+			testCase{
+				Name:        "limit-too-high",
+				Method:      http.MethodGet,
+				Path:        "/cars?limit=500",
+				ExpCode:     400,
+				ExpFields:   []string{"query.limit"},
+				ExpMessages: []string{"Limit must be at most 100"},
+			},
+			testCase{
+				Name:        "limit-zero",
+				Method:      http.MethodGet,
+				Path:        "/cars?limit=0",
+				ExpCode:     400,
+				ExpFields:   []string{"query.limit"},
+				ExpMessages: []string{"Limit must be at least 1"},
+			},
+			testCase{
+				Name:        "sort-not-allowed",
+				Method:      http.MethodGet,
+				Path:        "/cars?sort=price",
+				ExpCode:     400,
+				ExpFields:   []string{"query.sort"},
+				ExpMessages: []string{"Sort must be one of: make model year -make -model -year"},
+			},
+			testCase{
+				Name:        "query-wrong-type",
+				Method:      http.MethodGet,
+				Path:        "/cars?offset=ten",
+				ExpCode:     400,
+				ExpFields:   []string{"query.offset"},
+				ExpMessages: []string{"Offset must be of type number"},
+			},
+			testCase{
+				Name:        "pointer-wrong-type",
+				Method:      http.MethodGet,
+				Path:        "/cars?limit=abc",
+				ExpCode:     400,
+				ExpFields:   []string{"query.limit"},
+				ExpMessages: []string{"Limit must be of type number"},
+			},
+			testCase{
+				Name:    "valid-path",
+				Method:  http.MethodGet,
+				Path:    "/cars/f6a91ca9-a517-458a-80f1-2e31b58f9cc2",
+				ExpCode: 200,
+			},
+			testCase{
+				Name:        "path-not-uuid",
+				Method:      http.MethodGet,
+				Path:        "/cars/12",
+				ExpCode:     400,
+				ExpFields:   []string{"path.id"},
+				ExpMessages: []string{"ID is not a valid uuidv4"},
+			},
+			testCase{
+				Name:        "header-not-uuid",
+				Method:      http.MethodGet,
+				Path:        "/cars/f6a91ca9-a517-458a-80f1-2e31b58f9cc2",
+				Headers:     map[string]string{"X-Request-ID": "abc"},
+				ExpCode:     400,
+				ExpFields:   []string{"header.X-Request-ID"},
+				ExpMessages: []string{"Request ID is not a valid uuidv4"},
+			},
+		}
+
+		runTests(t, tests, GetRouter())
+	})
+
+	t.Run("json pointer paths", func(t *testing.T) {
+		req := performRequest(GetRouter(WithPathFormat(PathFormat{JSONPointer: true})), "GET", "/cars?limit=500", nil, nil)
+		assertCodeAndMessages(t, testCase{
+			ExpCode:   400,
+			ExpFields: []string{"/query/limit"},
+		}, req)
+	})
+}
+
+func TestRequestBody(t *testing.T) {
+	type renameExample struct {
+		ID   string `uri:"id" binding:"required"`
+		Name string `json:"name" binding:"required"`
+	}
+	binding.Validator = modelValidator
+	r := gin.New()
+	r.Use(mwParseValidation(&routerConfig{paths: DefaultPathFormat}))
+	rename := BindHandler(FromRequest, func(c *gin.Context, v *renameExample) {
+		c.JSON(http.StatusOK, v)
+	})
+	r.PUT("/things/:id", rename)
+	r.PUT("/strict/:id", RejectUnknownFields(), rename)
+
+	runTests(t, []testCase{
+		testCase{
+			Name:    "valid-body",
+			Method:  http.MethodPut,
+			Path:    "/things/1",
+			ExpCode: 200,
+			RawBody: `{"name": "widget", "color": "red"}`,
+		},
+		testCase{
+			Name:        "trailing-data",
+			Method:      http.MethodPut,
+			Path:        "/things/1",
+			ExpCode:     400,
+			ExpFields:   []string{"body"},
+			ExpMessages: []string{"Request body is not valid JSON at 1:20"},
+			RawBody:     `{"name": "widget"} {}`,
+		},
+		testCase{
+			Name:        "unknown-field-rejected",
+			Method:      http.MethodPut,
+			Path:        "/strict/1",
+			ExpCode:     400,
+			ExpFields:   []string{"body.color"},
+			ExpMessages: []string{"Color is not a recognized field"},
+			RawBody:     `{"name": "widget", "color": "red"}`,
+		},
+	}, r)
+}
+
+func TestWithSources(t *testing.T) {
+	type example struct {
+		ID    string   `uri:"id"`
+		Trace string   `header:"X-Trace"`
+		Tags  []string `form:"tag"`
+		Name  string   `json:"name"`
+	}
+	problems := []fieldProblem{
+		{Field: "id", path: fieldPath{{Name: "ID"}}},
+		{Field: "X-Trace", path: fieldPath{{Name: "Trace"}}},
+		{Field: "tag[1]", path: fieldPath{{Name: "Tags"}, {Index: "1", IsIndex: true}}},
+		{Field: "name", path: fieldPath{{Name: "Name"}}},
+		{Field: bodyField, path: fieldPath{{Name: bodyField}}},
+	}
+	keys := []string{}
+	for _, fp := range withSources(problems, reflect.TypeOf(example{}), DefaultPathFormat) {
+		keys = append(keys, fp.Field)
+	}
+	assert.Equal(t, []string{"path.id", "header.X-Trace", "query.tag[1]", "body.name", "body"}, keys)
+}
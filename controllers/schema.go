@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema describes a model the way OpenAPI 3 does, worked out from its
+// binding tags, ex: gte=3 on a string is minLength: 3
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+}
+
+// schemaFormats are the tags that map straight to an OpenAPI format.
+var schemaFormats = map[string]string{
+	"email":         "email",
+	"url":           "uri",
+	"uri":           "uri",
+	"uuid":          "uuid",
+	"uuid3":         "uuid",
+	"uuid4":         "uuid",
+	"uuid5":         "uuid",
+	"uuid_rfc4122":  "uuid",
+	"uuid3_rfc4122": "uuid",
+	"uuid4_rfc4122": "uuid",
+	"uuid5_rfc4122": "uuid",
+	"ipv4":          "ipv4",
+	"ipv6":          "ipv6",
+	"hostname":      "hostname",
+	"base64":        "byte",
+}
+
+// schemaPatterns are the tags that map to a regular expression.
+var schemaPatterns = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"lowercase":   `^[^A-Z]*$`,
+	"uppercase":   `^[^a-z]*$`,
+	"slug":        slugRegex.String(),
+}
+
+// SchemaFor will describe the given model, using the json name of each
+// field (or form, xml, uri - the same as the error keys).
+func SchemaFor(model interface{}) *Schema {
+	return typeSchema(reflect.TypeOf(model), map[reflect.Type]bool{})
+}
+
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	t = indirect(t)
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if t == fileHeaderType {
+		return &Schema{Type: "string", Format: "binary"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: typeSchema(t.Elem(), visiting)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: typeSchema(t.Elem(), visiting)}
+	case reflect.Struct:
+	default:
+		return &Schema{}
+	}
+
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	if visiting[t] {
+		// a model that contains itself - stop here rather than loop forever
+		return s
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || f.Tag.Get("json") == "-" {
+			continue
+		}
+		name := fieldKey(f)
+		prop := typeSchema(f.Type, visiting)
+		if applyBindingTag(prop, f.Tag.Get(bindingTag)) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+	return s
+}
+
+// applyBindingTag will add the constraints in a binding tag to the
+// schema, following dive into the items. It returns whether the field
+// is required.
+func applyBindingTag(s *Schema, binding string) bool {
+	if binding == "" || binding == "-" {
+		return false
+	}
+	required := false
+	cur := s
+	inKeys := false
+	for _, rule := range strings.Split(binding, ",") {
+		if inKeys {
+			inKeys = rule != "endkeys"
+			continue
+		}
+		if strings.Contains(rule, "|") {
+			applyOrRule(cur, rule)
+			continue
+		}
+		parts := strings.SplitN(rule, "=", 2)
+		tag, param := parts[0], ""
+		if len(parts) == 2 {
+			param = parts[1]
+		}
+
+		switch tag {
+		case "dive":
+			next := cur.Items
+			if next == nil {
+				next = cur.AdditionalProperties
+			}
+			if next == nil {
+				return required
+			}
+			cur = next
+		case "keys":
+			inKeys = true
+		case "required":
+			if cur == s {
+				required = true
+			} else if cur.Type == "string" {
+				cur.MinLength = intPtr(1)
+			}
+		case "len", "eq", "min", "gte", "gt", "max", "lte", "lt":
+			applyBound(cur, tag, param)
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				cur.Enum = append(cur.Enum, enumValue(cur, v))
+			}
+		case "unique":
+			cur.UniqueItems = true
+		case "maxfiles":
+			if n, err := strconv.Atoi(param); err == nil {
+				cur.MaxItems = intPtr(n)
+			}
+		case "datetime":
+			if param == "2006-01-02" {
+				cur.Format = "date"
+			} else {
+				cur.Format = "date-time"
+			}
+		default:
+			if format, ok := schemaFormats[tag]; ok {
+				cur.Format = format
+			} else if pattern, ok := schemaPatterns[tag]; ok && cur.Type == "string" {
+				cur.Pattern = pattern
+			}
+		}
+	}
+	return required
+}
+
+// applyOrRule will turn eq=a|eq=b into an enum - any other OR'd rule
+// can't be described, so it's left out.
+func applyOrRule(s *Schema, rule string) {
+	values := []interface{}{}
+	for _, alt := range strings.Split(rule, "|") {
+		if !strings.HasPrefix(alt, "eq=") {
+			return
+		}
+		values = append(values, enumValue(s, strings.TrimPrefix(alt, "eq=")))
+	}
+	s.Enum = append(s.Enum, values...)
+}
+
+// applyBound will set the length, size or value limit for a comparison
+// tag, depending on what type the schema is.
+func applyBound(s *Schema, tag string, param string) {
+	if s.Type == "string" && tag == "eq" {
+		s.Enum = append(s.Enum, param)
+		return
+	}
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	if s.Type == "integer" || s.Type == "number" {
+		switch tag {
+		case "len", "eq":
+			s.Enum = append(s.Enum, enumValue(s, param))
+		case "min", "gte":
+			s.Minimum = &n
+		case "gt":
+			s.Minimum, s.ExclusiveMinimum = &n, true
+		case "max", "lte":
+			s.Maximum = &n
+		case "lt":
+			s.Maximum, s.ExclusiveMaximum = &n, true
+		}
+		return
+	}
+
+	var min, max **int
+	switch s.Type {
+	case "string":
+		min, max = &s.MinLength, &s.MaxLength
+	case "array":
+		min, max = &s.MinItems, &s.MaxItems
+	case "object":
+		min, max = &s.MinProperties, &s.MaxProperties
+	default:
+		return
+	}
+	size := int(n)
+	switch tag {
+	case "len", "eq":
+		*min, *max = intPtr(size), intPtr(size)
+	case "min", "gte":
+		*min = intPtr(size)
+	case "gt":
+		*min = intPtr(size + 1)
+	case "max", "lte":
+		*max = intPtr(size)
+	case "lt":
+		*max = intPtr(size - 1)
+	}
+}
+
+// enumValue will convert a value from a tag to the schema's type.
+func enumValue(s *Schema, v string) interface{} {
+	switch s.Type {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return v
+}
+
+// sortedKeys will return the property names in order, so the output
+// doesn't change between runs.
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func intPtr(n int) *int {
+	return &n
+}
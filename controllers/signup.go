@@ -0,0 +1,6 @@
+package controllers
+
+import "github.com/mike-webster/golang-validation/models"
+
+// signupHandler will handle POST requests to /signup
+var signupHandler = BindHandler(FromBody, respondOK[models.SignupExample])
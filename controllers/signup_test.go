@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestPostSignup(t *testing.T) {
+	t.Run("SignupTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "nothing-provided",
+				Path:        "/signup",
+				ExpCode:     400,
+				ExpFields:   []string{"Username", "Email"},
+				ExpMessages: []string{"Username is required", "Email is required"},
+				Body:        models.SignupExample{},
+			},
+			testCase{
+				Name:        "username-not-alphanum",
+				Path:        "/signup",
+				ExpCode:     400,
+				ExpFields:   []string{"Username"},
+				ExpMessages: []string{"Username must be alphanumeric"},
+				Body: models.SignupExample{
+					Username: "not valid",
+					Email:    "someone@example.com",
+				},
+			},
+			testCase{
+				Name:        "email-not-valid",
+				Path:        "/signup",
+				ExpCode:     400,
+				ExpFields:   []string{"Email"},
+				ExpMessages: []string{"Invalid email format"},
+				Body: models.SignupExample{
+					Username: "someone",
+					Email:    "not-an-email",
+				},
+			},
+			testCase{
+				Name:        "email-too-long",
+				Path:        "/signup",
+				ExpCode:     400,
+				ExpFields:   []string{"Email"},
+				ExpMessages: []string{"Email must contain no more than 100 characters"},
+				Body: models.SignupExample{
+					Username: "someone",
+					Email:    strings.Repeat("a", 90) + "@example.com",
+				},
+			},
+			testCase{
+				Name:    "success",
+				Path:    "/signup",
+				ExpCode: 200,
+				Body: models.SignupExample{
+					Username: "someone",
+					Email:    "someone@example.com",
+				},
+			},
+		}
+		runTests(t, tests, GetRouter())
+	})
+}
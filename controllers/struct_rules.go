@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"log"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+// Validatable is implemented by models with rules that don't fit in a
+// single field's tag (ex: EndTime within 8 hours of StartTime). Validate
+// runs after the binding tags - even if some of them failed - and should
+// return a *models.RuleError or models.RuleErrors naming the fields to
+// blame, or nil.
+type Validatable interface {
+	Validate() error
+}
+
+// RuleDescriber can be implemented by a Validatable model to list the
+// rules its Validate checks, so their messages are in the model's JSON
+// Schema along with those from the binding tags.
+type RuleDescriber interface {
+	Rules() models.RuleErrors
+}
+
+var validatableType = reflect.TypeOf((*Validatable)(nil)).Elem()
+
+var (
+	hookedTypesMu sync.Mutex
+	hookedTypes   = map[reflect.Type]bool{}
+)
+
+// RegisterStructValidation will add a struct level func for the given
+// model types, for rules that would rather use the validator directly
+// than implement Validatable. Report failures with sl.ReportError, and
+// register a message for the tag used. Call it before GetRouter.
+func RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
+	modelValidator.Engine().(*validator.Validate).RegisterStructValidation(fn, types...)
+}
+
+// registerStructHooks will hook up Validate for the given type, and for
+// any struct it's built from, if they implement Validatable.
+func registerStructHooks(t reflect.Type) {
+	hookedTypesMu.Lock()
+	defer hookedTypesMu.Unlock()
+	registerStructHooksLocked(t)
+}
+
+func registerStructHooksLocked(t reflect.Type) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) || hookedTypes[t] {
+		return
+	}
+	hookedTypes[t] = true
+
+	if t.Implements(validatableType) || reflect.PtrTo(t).Implements(validatableType) {
+		RegisterStructValidation(validateHook, reflect.Zero(t).Interface())
+	}
+	for i := 0; i < t.NumField(); i++ {
+		registerStructHooksLocked(t.Field(i).Type)
+	}
+}
+
+// validateHook is the struct level func that calls a model's Validate and
+// reports each field it blames, so they come back like any other field error.
+func validateHook(sl validator.StructLevel) {
+	current := sl.Current()
+	ptr := reflect.New(current.Type())
+	ptr.Elem().Set(current)
+
+	err := ptr.Interface().(Validatable).Validate()
+	switch e := err.(type) {
+	case nil:
+		return
+	case *models.RuleError:
+		reportRule(sl, e)
+	case models.RuleErrors:
+		for _, re := range e {
+			reportRule(sl, re)
+		}
+	default:
+		log.Panicf("%s.Validate must return a *models.RuleError, got: %v", current.Type(), err)
+	}
+}
+
+func reportRule(sl validator.StructLevel, e *models.RuleError) {
+	for _, name := range e.Fields {
+		var value interface{}
+		if f := sl.Current().FieldByName(name); f.IsValid() && f.CanInterface() {
+			value = f.Interface()
+		}
+		sl.ReportError(value, name, name, e.Tag, e.Param)
+	}
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/mike-webster/golang-validation/models"
+)
+
+type rangeExample struct {
+	Min int `binding:"gte=0"`
+	Max int `binding:"gte=0"`
+}
+
+func (r *rangeExample) Validate() error {
+	if r.Min > r.Max {
+		return &models.RuleError{Fields: []string{"Min", "Max"}, Tag: "range"}
+	}
+	return nil
+}
+
+type rangesExample struct {
+	Ranges []rangeExample `binding:"dive"`
+}
+
+func TestStructRules(t *testing.T) {
+	registerStructHooks(reflect.TypeOf(rangesExample{}))
+	m := NewMessageCatalog()
+	m.Register("range", "{field} is out of order")
+
+	t.Run("BlamesEveryField", func(t *testing.T) {
+		obj := rangeExample{Min: 5, Max: 1}
+		assert.Equal(t, "Min is out of order", m.Render(fieldErrorFor(t, obj, "Min")))
+		assert.Equal(t, "Max is out of order", m.Render(fieldErrorFor(t, obj, "Max")))
+	})
+	t.Run("Nested", func(t *testing.T) {
+		obj := rangesExample{Ranges: []rangeExample{{Min: 0, Max: 1}, {Min: 3, Max: 2}}}
+		e := fieldErrorFor(t, obj, "Ranges[1].Min")
+		assert.Equal(t, "range", e.Tag())
+	})
+	t.Run("Passes", func(t *testing.T) {
+		err := modelValidator.ValidateStruct(&rangeExample{Min: 1, Max: 2})
+		assert.Equal(t, nil, err)
+	})
+}
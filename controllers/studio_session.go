@@ -0,0 +1,11 @@
+package controllers
+
+import "github.com/mike-webster/golang-validation/models"
+
+func init() {
+	Messages.Register("max_session", "{field} must be no more than {param} after the start time")
+	RegisterDurationParam("max_session")
+}
+
+// studioSessionHandler will handle POST requests to /studio-session
+var studioSessionHandler = BindHandler(FromBody, respondOK[models.StudioSessionExample])
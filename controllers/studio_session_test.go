@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestPostStudioSession(t *testing.T) {
+	start := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	t.Run("StudioSessionTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "end-before-start",
+				Path:        "/studio-session",
+				ExpCode:     400,
+				ExpFields:   []string{"EndTime"},
+				ExpMessages: []string{"End time must be after Start time"},
+				Body: models.StudioSessionExample{
+					BandName:    "TheBand",
+					BandMembers: 4,
+					StartTime:   start,
+					EndTime:     start.Add(-time.Hour),
+				},
+			},
+			testCase{
+				Name:        "too-many-band-members",
+				Path:        "/studio-session",
+				ExpCode:     400,
+				ExpFields:   []string{"BandMembers"},
+				ExpMessages: []string{"Band members must be at most 8"},
+				Body: models.StudioSessionExample{
+					BandName:    "TheBand",
+					BandMembers: 9,
+					StartTime:   start,
+					EndTime:     start.Add(time.Hour),
+				},
+			},
+			testCase{
+				Name:        "session-too-long",
+				Path:        "/studio-session",
+				ExpCode:     400,
+				ExpFields:   []string{"EndTime"},
+				ExpMessages: []string{"End time must be no more than 8 hours after the start time"},
+				Body: models.StudioSessionExample{
+					BandName:    "TheBand",
+					BandMembers: 4,
+					StartTime:   start,
+					EndTime:     start.Add(9 * time.Hour),
+				},
+			},
+			testCase{
+				Name:        "session-too-long-spanish",
+				Path:        "/studio-session",
+				ExpCode:     400,
+				ExpFields:   []string{"EndTime"},
+				ExpMessages: []string{"Hora de finalización no puede terminar más de 8 horas después de la hora de inicio"},
+				Headers:     map[string]string{"Accept-Language": "es"},
+				Body: models.StudioSessionExample{
+					BandName:    "TheBand",
+					BandMembers: 4,
+					StartTime:   start,
+					EndTime:     start.Add(9 * time.Hour),
+				},
+			},
+			testCase{
+				Name:        "session-too-long-and-bad-name",
+				Path:        "/studio-session",
+				ExpCode:     400,
+				ExpFields:   []string{"BandName", "EndTime"},
+				ExpMessages: []string{"Band name must be alphanumeric", "End time must be no more than 8 hours after the start time"},
+				Body: models.StudioSessionExample{
+					BandName:    "The Band!",
+					BandMembers: 4,
+					StartTime:   start,
+					EndTime:     start.Add(9 * time.Hour),
+				},
+			},
+			testCase{
+				Name:    "success",
+				Path:    "/studio-session",
+				ExpCode: 200,
+				Body: models.StudioSessionExample{
+					BandName:    "TheBand",
+					BandMembers: 4,
+					StartTime:   start,
+					EndTime:     start.Add(8 * time.Hour),
+				},
+			},
+		}
+		runTests(t, tests, GetRouter())
+	})
+}
@@ -11,14 +11,18 @@ import (
 
 	"github.com/bmizerany/assert"
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 type testCase struct {
 	Name        string
 	Path        string
+	Method      string // defaults to POST
 	ExpCode     int
 	ExpFields   []string
 	ExpMessages []string
+	Headers     map[string]string
+	RawBody     string      // sent as-is instead of Body, for bodies that aren't valid JSON
 	Body        interface{} // I made this an interface so that it could be used by all test cases
 }
 
@@ -49,17 +53,41 @@ func performRequest(r http.Handler,
 // runTests will take a slice of test cases and a gin router and perform
 // all of the assertions for the tests.
 func runTests(t *testing.T, cases []testCase, r *gin.Engine) {
-	testHeaders := map[string]string{"Content-Type": "application/json"}
 	for _, iCase := range cases {
 		t.Run(iCase.Name, func(t *testing.T) {
+			testHeaders := map[string]string{"Content-Type": "application/json"}
+			for k, v := range iCase.Headers {
+				testHeaders[k] = v
+			}
 			bytes, _ := json.Marshal(iCase.Body)
-			req := performRequest(r, "POST", iCase.Path, &bytes, testHeaders)
+			if iCase.RawBody != "" {
+				bytes = []byte(iCase.RawBody)
+			}
+			method := iCase.Method
+			if method == "" {
+				method = "POST"
+			}
+			req := performRequest(r, method, iCase.Path, &bytes, testHeaders)
 
 			assertCodeAndMessages(t, iCase, req)
 		})
 	}
 }
 
+// fieldErrorFor will validate obj and return the error for the field at
+// the given path (ex: Items[2].Name), failing the test if there isn't one.
+func fieldErrorFor(t *testing.T, obj interface{}, path string) validator.FieldError {
+	err := modelValidator.Engine().(*validator.Validate).Struct(obj)
+	errs, _ := err.(validator.ValidationErrors)
+	for _, e := range errs {
+		if newFieldError(e).Namespace == path {
+			return e
+		}
+	}
+	t.Fatalf("no validation error for %s: %v", path, err)
+	return nil
+}
+
 // assertCodeAndMessages will check the code and messages in the given test case
 // to ensure the response values were what we were expecting.
 //
@@ -0,0 +1,290 @@
+package controllers
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// TimeZone is the zone business hours are checked in, dates without a
+// zone in a tag's param are read in, and times in messages are shown in.
+// Set it before GetRouter, ex: TimeZone, _ = time.LoadLocation("America/Chicago")
+var TimeZone = time.UTC
+
+// now is swapped out in tests.
+var now = time.Now
+
+var timeType = reflect.TypeOf(time.Time{})
+
+var (
+	durationParamsMu sync.RWMutex
+	durationParams   = map[string]bool{"withinfield": true}
+)
+
+// RegisterDurationParam will have messages write the given tag's param as
+// a duration, ex: 8h0m0s -> 8 hours. Use it for tags a model's Validate
+// reports, with a param time.ParseDuration can read.
+func RegisterDurationParam(tag string) {
+	durationParamsMu.Lock()
+	defer durationParamsMu.Unlock()
+	durationParams[tag] = true
+}
+
+// isDurationParam will report whether the given tag's param is a duration.
+func isDurationParam(tag string) bool {
+	durationParamsMu.RLock()
+	defer durationParamsMu.RUnlock()
+	return durationParams[tag]
+}
+
+// dateParamLayouts are the formats accepted for the date in after= and before=.
+var dateParamLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"}
+
+func init() {
+	tags := []struct {
+		tag      string
+		fn       validator.Func
+		template string
+	}{
+		{"future", isFuture, "{field} must be in the future"},
+		{"past", isPast, "{field} must be in the past"},
+		{"after", isAfter, "{field} must be after {param}"},
+		{"before", isBefore, "{field} must be before {param}"},
+		{"withinfield", isWithinField, "{field} must be within {paramValue} of {paramField}"},
+		{"businesshours", isBusinessHours, "{field} must be between {param}"},
+	}
+	for _, t := range tags {
+		if err := RegisterValidation(t.tag, t.fn, t.template); err != nil {
+			panic(err)
+		}
+	}
+
+	paramChecks["after"] = func(param string) error {
+		_, err := parseDateParam("after", param)
+		return err
+	}
+	paramChecks["before"] = func(param string) error {
+		_, err := parseDateParam("before", param)
+		return err
+	}
+	paramChecks["withinfield"] = func(param string) error {
+		_, _, err := parseWithinField(param)
+		return err
+	}
+	paramChecks["businesshours"] = func(param string) error {
+		_, _, err := parseHours(param)
+		return err
+	}
+}
+
+// fieldTime will return the field's value as a time, if it is one.
+func fieldTime(field reflect.Value) (time.Time, bool) {
+	if field.Type() != timeType {
+		return time.Time{}, false
+	}
+	return field.Interface().(time.Time), true
+}
+
+// isFuture will check the field is a time after now.
+func isFuture(fl validator.FieldLevel) bool {
+	t, ok := fieldTime(fl.Field())
+	return ok && t.After(now())
+}
+
+// isPast will check the field is a time before now.
+func isPast(fl validator.FieldLevel) bool {
+	t, ok := fieldTime(fl.Field())
+	return ok && t.Before(now())
+}
+
+// isAfter will check the field is a time after the date in param,
+// ex: after=2019-01-01
+func isAfter(fl validator.FieldLevel) bool {
+	t, ok := fieldTime(fl.Field())
+	after, err := parseDateParam("after", fl.Param())
+	return ok && err == nil && t.After(after)
+}
+
+// isBefore will check the field is a time before the date in param,
+// ex: before=2019-01-01
+func isBefore(fl validator.FieldLevel) bool {
+	t, ok := fieldTime(fl.Field())
+	before, err := parseDateParam("before", fl.Param())
+	return ok && err == nil && t.Before(before)
+}
+
+// isWithinField will check the field is a time no further than the
+// duration from the other field, either way, ex: withinfield=StartTime 8h
+func isWithinField(fl validator.FieldLevel) bool {
+	t, ok := fieldTime(fl.Field())
+	if !ok {
+		return false
+	}
+	field, d, err := parseWithinField(fl.Param())
+	if err != nil {
+		return false
+	}
+
+	parent := reflect.Indirect(fl.Parent())
+	other, ok := fieldTime(reflect.Indirect(parent.FieldByName(field)))
+	if !ok || other.IsZero() {
+		// nothing to compare with - required on the other field will catch it
+		return true
+	}
+	diff := t.Sub(other)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= d
+}
+
+// isBusinessHours will check the field is a time on a weekday, between
+// the hours in param, in TimeZone, ex: businesshours=09:00-17:00
+func isBusinessHours(fl validator.FieldLevel) bool {
+	t, ok := fieldTime(fl.Field())
+	if !ok {
+		return false
+	}
+	start, end, err := parseHours(fl.Param())
+	if err != nil {
+		return false
+	}
+	t = t.In(TimeZone)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	return minute >= start && minute < end
+}
+
+// parseDateParam will read the date in an after= or before= param, in
+// TimeZone.
+func parseDateParam(tag string, param string) (time.Time, error) {
+	for _, layout := range dateParamLayouts {
+		if t, err := time.ParseInLocation(layout, param, TimeZone); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s: bad date %q", tag, param)
+}
+
+// parseWithinField will read the other field and the duration in a
+// withinfield param, ex: StartTime 8h
+func parseWithinField(param string) (string, time.Duration, error) {
+	fields, values := paramFields("withinfield", param)
+	if len(fields) != 1 || len(values) != 1 {
+		return "", 0, fmt.Errorf("withinfield: bad param %q", param)
+	}
+	d, err := time.ParseDuration(values[0])
+	if err != nil {
+		return "", 0, fmt.Errorf("withinfield: bad duration %q", values[0])
+	}
+	return fields[0], d, nil
+}
+
+// parseHours will return the minutes into the day a window like
+// 09:00-17:00 starts and ends.
+func parseHours(param string) (int, int, error) {
+	parts := strings.SplitN(param, "-", 2)
+	if len(parts) == 2 {
+		start, err1 := time.Parse("15:04", parts[0])
+		end, err2 := time.Parse("15:04", parts[1])
+		if err1 == nil && err2 == nil && end.After(start) {
+			return start.Hour()*60 + start.Minute(), end.Hour()*60 + end.Minute(), nil
+		}
+	}
+	return 0, 0, fmt.Errorf("businesshours: bad param %q", param)
+}
+
+// businessHours will write a businesshours param,
+// ex: 09:00-17:00 -> 9:00 AM and 5:00 PM, Monday to Friday (UTC)
+func (l *Localizer) businessHours(param string) string {
+	start, end, err := parseHours(param)
+	if err != nil {
+		return param
+	}
+	clock := func(m int) string {
+		return time.Date(2000, 1, 1, m/60, m%60, 0, 0, time.UTC).Format(l.text("clock"))
+	}
+	return strings.NewReplacer(
+		"{start}", clock(start),
+		"{end}", clock(end),
+		"{zone}", TimeZone.String(),
+	).Replace(l.text("business_days"))
+}
+
+// date will write a time in TimeZone, leaving off the time of day if it's
+// midnight, ex: March 1, 2019 or March 1, 2019 at 9:30 AM CST
+func (l *Localizer) date(t time.Time) string {
+	t = t.In(TimeZone)
+	date := strings.NewReplacer(
+		"{month}", l.month(t.Month()),
+		"{day}", strconv.Itoa(t.Day()),
+		"{year}", strconv.Itoa(t.Year()),
+	).Replace(l.text("date"))
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return date
+	}
+	return strings.NewReplacer(
+		"{date}", date,
+		"{time}", t.Format(l.text("clock")),
+		"{zone}", t.Format("MST"),
+	).Replace(l.text("date_time"))
+}
+
+// durationUnits are the units a duration is spelled out in, biggest first.
+var durationUnits = []struct {
+	size time.Duration
+	unit string
+}{
+	{24 * time.Hour, "day"},
+	{time.Hour, "hour"},
+	{time.Minute, "minute"},
+	{time.Second, "second"},
+}
+
+// duration will spell out a duration, ex: 90m -> 1 hour and 30 minutes
+func (l *Localizer) duration(d time.Duration) string {
+	parts := []string{}
+	for _, u := range durationUnits {
+		n := d / u.size
+		d -= n * u.size
+		if n > 0 {
+			parts = append(parts, l.count(int(n), u.unit))
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return l.count(0, "second")
+	case 1:
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], l.text("duration_separator")) +
+		l.text("duration_and") + parts[len(parts)-1]
+}
+
+// count will write a number of the given unit, ex: 2 hours
+func (l *Localizer) count(n int, unit string) string {
+	return strings.NewReplacer(
+		"{count}", strconv.Itoa(n),
+		"{unit}", l.unitWord(unit, n),
+	).Replace(l.text("count"))
+}
+
+// layoutReplacer turns the parts of a Go time layout into the letters
+// people are used to seeing in a date format.
+var layoutReplacer = strings.NewReplacer(
+	"2006", "YYYY", "01", "MM", "02", "DD", "15", "HH", "03", "hh", "04", "mm", "05", "ss",
+	"PM", "AM/PM", "Jan", "MMM", "Mon", "ddd", "MST", "TZ", "Z07:00", "±hh:mm",
+)
+
+// humanLayout will show a Go time layout as a date format,
+// ex: 2006-01-02 -> YYYY-MM-DD
+func humanLayout(layout string) string {
+	return layoutReplacer.Replace(layout)
+}
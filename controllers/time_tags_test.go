@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type timeTagsExample struct {
+	Opens    time.Time `binding:"required,future,businesshours=09:00-17:00"`
+	Closes   time.Time `binding:"required,gtfield=Opens,withinfield=Opens 90m"`
+	Founded  time.Time `binding:"omitempty,past,after=1900-01-01"`
+	Deadline time.Time `binding:"omitempty,before=2020-01-01T12:30"`
+	Birthday string    `binding:"omitempty,datetime=2006-01-02"`
+}
+
+func TestTimeTags(t *testing.T) {
+	cst := time.FixedZone("CST", -6*60*60)
+	oldNow, oldZone := now, TimeZone
+	now = func() time.Time { return time.Date(2019, 3, 1, 12, 0, 0, 0, cst) }
+	TimeZone = cst
+	defer func() { now, TimeZone = oldNow, oldZone }()
+
+	binding.Validator = modelValidator
+	r := gin.New()
+	r.Use(mwParseValidation(&routerConfig{paths: DefaultPathFormat}))
+	r.POST("/times", BindHandler(FromJSON, respondOK[timeTagsExample]))
+
+	// a monday
+	opens := time.Date(2019, 3, 4, 10, 0, 0, 0, cst)
+	t.Run("TimeTagTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:    "valid",
+				Path:    "/times",
+				ExpCode: 200,
+				Body: timeTagsExample{
+					Opens:    opens,
+					Closes:   opens.Add(time.Hour),
+					Founded:  time.Date(1950, 1, 1, 0, 0, 0, 0, cst),
+					Deadline: time.Date(2019, 12, 1, 0, 0, 0, 0, cst),
+					Birthday: "1980-07-04",
+				},
+			},
+			testCase{
+				Name:      "not-in-the-future",
+				Path:      "/times",
+				ExpCode:   400,
+				ExpFields: []string{"Opens"},
+				ExpMessages: []string{
+					"Opens must be in the future",
+				},
+				Body: timeTagsExample{
+					Opens:  opens.AddDate(0, 0, -7),
+					Closes: opens.AddDate(0, 0, -7).Add(time.Hour),
+				},
+			},
+			testCase{
+				Name:      "outside-business-hours",
+				Path:      "/times",
+				ExpCode:   400,
+				ExpFields: []string{"Opens"},
+				ExpMessages: []string{
+					"Opens must be between 9:00 AM and 5:00 PM, Monday to Friday (CST)",
+				},
+				Body: timeTagsExample{
+					// 8am in CST, even though it's sent as UTC
+					Opens:  opens.Add(-2 * time.Hour).UTC(),
+					Closes: opens,
+				},
+			},
+			testCase{
+				Name:      "closes-before-opens",
+				Path:      "/times",
+				ExpCode:   400,
+				ExpFields: []string{"Closes"},
+				ExpMessages: []string{
+					"Closes must be after Opens",
+				},
+				Body: timeTagsExample{
+					Opens:  opens,
+					Closes: opens.Add(-time.Hour),
+				},
+			},
+			testCase{
+				Name:      "closes-too-late",
+				Path:      "/times",
+				ExpCode:   400,
+				ExpFields: []string{"Closes"},
+				ExpMessages: []string{
+					"Closes must be within 1 hour and 30 minutes of Opens",
+				},
+				Body: timeTagsExample{
+					Opens:  opens,
+					Closes: opens.Add(2 * time.Hour),
+				},
+			},
+			testCase{
+				Name:      "dates-out-of-range",
+				Path:      "/times",
+				ExpCode:   400,
+				ExpFields: []string{"Founded", "Deadline", "Birthday"},
+				ExpMessages: []string{
+					"Founded must be after January 1, 1900",
+					"Deadline must be before January 1, 2020 at 12:30 PM CST",
+					"Birthday must be a date formatted as YYYY-MM-DD",
+				},
+				Body: timeTagsExample{
+					Opens:    opens,
+					Closes:   opens.Add(time.Hour),
+					Founded:  time.Date(1850, 1, 1, 0, 0, 0, 0, cst),
+					Deadline: time.Date(2021, 1, 1, 0, 0, 0, 0, cst),
+					Birthday: "07/04/1980",
+				},
+			},
+		}
+		runTests(t, tests, r)
+	})
+
+	t.Run("Localized", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "spanish-closes-first",
+				Path:        "/times",
+				ExpCode:     400,
+				ExpFields:   []string{"Closes"},
+				ExpMessages: []string{"Closes debe ser posterior a Opens"},
+				Headers:     map[string]string{"Accept-Language": "es"},
+				Body: timeTagsExample{
+					Opens:  opens,
+					Closes: opens.Add(-time.Hour),
+				},
+			},
+			testCase{
+				Name:        "spanish-closes-too-late",
+				Path:        "/times",
+				ExpCode:     400,
+				ExpFields:   []string{"Closes"},
+				ExpMessages: []string{"Closes debe estar a menos de 1 hora y 30 minutos de Opens"},
+				Headers:     map[string]string{"Accept-Language": "es"},
+				Body: timeTagsExample{
+					Opens:  opens,
+					Closes: opens.Add(2 * time.Hour),
+				},
+			},
+			testCase{
+				Name:      "german-dates",
+				Path:      "/times",
+				ExpCode:   400,
+				ExpFields: []string{"Opens", "Founded", "Deadline"},
+				ExpMessages: []string{
+					"Opens muss zwischen 09:00 und 17:00 Uhr, Montag bis Freitag (CST) liegen",
+					"Founded muss nach dem 1. Januar 1900 liegen",
+					"Deadline muss vor dem 1. Januar 2020 um 12:30 Uhr CST liegen",
+				},
+				Headers: map[string]string{"Accept-Language": "de"},
+				Body: timeTagsExample{
+					Opens:    opens.Add(-2 * time.Hour),
+					Closes:   opens,
+					Founded:  time.Date(1850, 1, 1, 0, 0, 0, 0, cst),
+					Deadline: time.Date(2021, 1, 1, 0, 0, 0, 0, cst),
+				},
+			},
+		}
+		runTests(t, tests, r)
+	})
+}
+
+func TestTimeBadParams(t *testing.T) {
+	type badTimes struct {
+		Opens   time.Time `binding:"businesshours=17:00-09:00"`
+		Closes  time.Time `binding:"withinfield=Opens"`
+		Founded time.Time `binding:"after=last year,before=2020-13-01"`
+		Ends    time.Time `binding:"withinfield=Opens soon"`
+	}
+	assert.Equal(t, []string{
+		`after: bad date "last year"`,
+		`before: bad date "2020-13-01"`,
+		`businesshours: bad param "17:00-09:00"`,
+		`withinfield: bad duration "soon"`,
+		`withinfield: bad param "Opens"`,
+	}, BadParams(badTimes{}))
+}
+
+func TestDuration(t *testing.T) {
+	l := Messages.localizer()
+	assert.Equal(t, "8 hours", l.duration(8*time.Hour))
+	assert.Equal(t, "1 hour and 30 minutes", l.duration(90*time.Minute))
+	assert.Equal(t, "2 days, 1 hour and 1 second", l.duration(49*time.Hour+time.Second))
+	assert.Equal(t, "0 seconds", l.duration(0))
+
+	assert.Equal(t, "2 días, 1 hora y 1 segundo", NegotiateLocale("es").duration(49*time.Hour+time.Second))
+	assert.Equal(t, "1 Stunde und 30 Minuten", NegotiateLocale("de").duration(90*time.Minute))
+	assert.Equal(t, "1時間30分", NegotiateLocale("ja").duration(90*time.Minute))
+}
@@ -0,0 +1,6 @@
+package controllers
+
+import "github.com/mike-webster/golang-validation/models"
+
+// uploadCsvsHandler will handle POST requests to /upload-csvs
+var uploadCsvsHandler = BindHandler(FromBody, respondOK[models.UploadCsvsExample])
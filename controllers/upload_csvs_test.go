@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/mike-webster/golang-validation/models"
+)
+
+func TestPostUploadCsvs(t *testing.T) {
+	row := []string{"alpha", "bravo", "charlie"}
+	t.Run("UploadCsvsTests", func(t *testing.T) {
+		tests := []testCase{
+			testCase{
+				Name:        "content-not-provided",
+				Path:        "/upload-csvs",
+				ExpCode:     400,
+				ExpFields:   []string{"Content"},
+				ExpMessages: []string{"Content is required"},
+				Body:        models.UploadCsvsExample{},
+			},
+			testCase{
+				Name:        "too-many-rows",
+				Path:        "/upload-csvs",
+				ExpCode:     400,
+				ExpFields:   []string{"Content"},
+				ExpMessages: []string{"Content must contain no more than 5 entries"},
+				Body: models.UploadCsvsExample{
+					Content: [][]string{row, row, row, row, row, row},
+				},
+			},
+			testCase{
+				Name:        "row-too-short",
+				Path:        "/upload-csvs",
+				ExpCode:     400,
+				ExpFields:   []string{"Content[1]"},
+				ExpMessages: []string{"Content entry 2 must contain at least 3 entries"},
+				Body: models.UploadCsvsExample{
+					Content: [][]string{row, []string{"alpha", "bravo"}},
+				},
+			},
+			testCase{
+				Name:        "cell-not-alpha",
+				Path:        "/upload-csvs",
+				ExpCode:     400,
+				ExpFields:   []string{"Content[0][2]"},
+				ExpMessages: []string{"Content entry 1, item 3 must contain only letters"},
+				Body: models.UploadCsvsExample{
+					Content: [][]string{[]string{"alpha", "bravo", "charlie7"}},
+				},
+			},
+			testCase{
+				Name:        "cell-too-short",
+				Path:        "/upload-csvs",
+				ExpCode:     400,
+				ExpFields:   []string{"Content[0][0]"},
+				ExpMessages: []string{"Content entry 1, item 1 must contain at least 5 characters"},
+				Body: models.UploadCsvsExample{
+					Content: [][]string{[]string{"abc", "bravo", "charlie"}},
+				},
+			},
+			testCase{
+				Name:    "success",
+				Path:    "/upload-csvs",
+				ExpCode: 200,
+				Body: models.UploadCsvsExample{
+					Content: [][]string{row, row},
+				},
+			},
+		}
+		runTests(t, tests, GetRouter())
+	})
+}
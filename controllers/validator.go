@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// modelValidator is what GetRouter hands to gin for validating bound
+// models - it's gin's default validator, except the errors it returns
+// remember which model failed so we can look up the model's struct tags.
+var modelValidator = &structValidator{}
+
+var _ binding.StructValidator = modelValidator
+
+// fieldError is everything we need to know about a failed field to
+// describe it. It's filled in from a validator.FieldError, or built by
+// hand for errors that happen before validation (ex: a body that isn't
+// valid JSON).
+type fieldError struct {
+	// Namespace is the path to the field from the top level struct, using
+	// Go field names (ex: Items[2].Name)
+	Namespace string
+	Tag       string
+	Param     string
+	Kind      reflect.Kind
+	Type      reflect.Type
+	Value     interface{}
+}
+
+// newFieldError will copy what we need out of a validator.FieldError.
+func newFieldError(e validator.FieldError) *fieldError {
+	ns := e.StructNamespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		// drop the name of the top level struct
+		ns = ns[i+1:]
+	} else {
+		ns = e.StructField()
+	}
+	return &fieldError{
+		Namespace: ns,
+		Tag:       e.Tag(),
+		Param:     e.Param(),
+		Kind:      e.Kind(),
+		Type:      e.Type(),
+		Value:     e.Value(),
+	}
+}
+
+// modelErrors are the validation errors for a single bound model.
+type modelErrors struct {
+	Type   reflect.Type
+	Errors validator.ValidationErrors
+}
+
+// Error will return the underlying validation errors' text.
+func (me *modelErrors) Error() string {
+	return me.Errors.Error()
+}
+
+type structValidator struct {
+	once     sync.Once
+	validate *validator.Validate
+}
+
+// ValidateStruct receives any kind of type, but only performs validation
+// on a struct or pointer to a struct.
+func (v *structValidator) ValidateStruct(obj interface{}) error {
+	value := reflect.ValueOf(obj)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	v.lazyinit()
+	err := v.validate.Struct(obj)
+	if errs, ok := err.(validator.ValidationErrors); ok {
+		return &modelErrors{Type: value.Type(), Errors: errs}
+	}
+	return err
+}
+
+// Engine returns the underlying *validator.Validate.
+func (v *structValidator) Engine() interface{} {
+	v.lazyinit()
+	return v.validate
+}
+
+func (v *structValidator) lazyinit() {
+	v.once.Do(func() {
+		v.validate = validator.New()
+		v.validate.SetTagName(bindingTag)
+	})
+}
+
+// paramChecks are the tags whose param GetRouter checks on every model
+// when it's built, so a typo in a tag turns up then instead of when a
+// request is being handled.
+var paramChecks = map[string]func(param string) error{}
+
+// RegisterValidation will add a custom tag to the validator gin binds
+// models with, along with the message template used when it fails. Like
+// the rest of the setup this isn't thread-safe - call it before GetRouter.
+//
+// ex: RegisterValidation("slug", isSlug, "{field} must be a slug")
+func RegisterValidation(tag string, fn validator.Func, template string) error {
+	v := modelValidator.Engine().(*validator.Validate)
+	if err := v.RegisterValidation(tag, fn); err != nil {
+		return err
+	}
+	Messages.Register(tag, template)
+	return nil
+}
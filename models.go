@@ -1,29 +0,0 @@
-package wyzvalidator
-
-// type SignupExample struct {
-// 	Username string `binding:"required,gte=5,lte=30,alphanum"`
-// 	Email    string `binding:"required,email,max=100"`
-// }
-
-// type StudioSessionExample struct {
-// 	BandName    string    `binding:"required,max=30,alphanum"`
-// 	BandMembers int       `binding:"required,numeric,max=8"`
-// 	StartTime   time.Time `binding:"required"`
-// 	EndTime     time.Time `binding:"required,gtfield=StartTime"`
-// }
-
-// type PartnershipRequestExample struct {
-// 	CompanyName string `binding:"required,max=50,alphanum"`
-// 	Website     string `binding:"required,url"`
-// 	Referrer    string `binding:"uri"`
-// }
-
-// type PostCoordinatesExample struct {
-// 	UserID int    `binding:"required,int"`
-// 	Lat    string `binding:"required,latitude"`
-// 	Long   string `binding:"required,longitude"`
-// }
-
-// type UploadCsvsExample struct {
-// 	Content [][]string `binding:"required,max=5,dive,gte=3,max=50,dive,required,gte=5,max=1000,alpha"`
-// }
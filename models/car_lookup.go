@@ -0,0 +1,8 @@
+package models
+
+// CarLookupExample represents a request for a single car by its ID, with an
+// optional ID the client can trace the request by
+type CarLookupExample struct {
+	ID        string `uri:"id" binding:"required,uuid4"`
+	RequestID string `header:"X-Request-ID" label:"Request ID" binding:"omitempty,uuid4"`
+}
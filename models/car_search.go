@@ -0,0 +1,10 @@
+package models
+
+// CarSearchExample represents a page of cars from GET /cars - how many to
+// return, where to start, and what to sort by (a leading - sorts descending).
+// Limit is a pointer so ?limit=0 is checked instead of taken as not sent.
+type CarSearchExample struct {
+	Limit  *int   `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"min=0"`
+	Sort   string `form:"sort" binding:"omitempty,oneof=make model year -make -model -year"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// PostCoordinatesExample represents a user checking in from somewhere
+type PostCoordinatesExample struct {
+	UserID int    `binding:"required,min=1"`
+	Lat    string `binding:"required,latitude" label:"Latitude"`
+	Long   string `binding:"required,longitude" label:"Longitude"`
+}
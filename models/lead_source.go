@@ -2,6 +2,7 @@ package models
 
 // LeadSourceExample represents a potential lead
 type LeadSourceExample struct {
-	VisitorID string `binding:"required,uuid4"`
-	Source    string `binding:"required,eq=google|eq=yahoo|eq=other"`
+	VisitorID    string `binding:"required,uuid4"`
+	Source       string `binding:"required,eq=google|eq=yahoo|eq=other"`
+	SourceDetail string `binding:"required_if=Source other"`
 }
@@ -0,0 +1,8 @@
+package models
+
+// PartnershipRequestExample represents a company asking to partner with us
+type PartnershipRequestExample struct {
+	CompanyName string `binding:"required,max=50,alphanum"`
+	Website     string `binding:"required,url"`
+	Referrer    string `binding:"omitempty,uri"`
+}
@@ -2,8 +2,8 @@ package models
 
 // PasswordExample represents a users password
 type PasswordExample struct {
-	Username        string `binding:"required,gte=5,lte=30,alphanum"`
+	Username        string `binding:"required,gte=5,lte=30,alphanum" async:"available=usernames"`
 	OldPassword     string `binding:"required,gte=8,lte=30"`
-	Password        string `binding:"required,gte=8,lte=30,nefield=OldPassword,excludes=password,excludesrune=^"`
+	Password        string `binding:"required,gte=8,lte=30,nefield=OldPassword,excludes=password,excludesrune=^,charclasses=3,notcontainsfield=Username,minentropy=40,notbreached"`
 	PasswordConfirm string `binding:"required,gte=8,lte=30,eqfield=Password,nefield=OldPassword"`
 }
\ No newline at end of file
@@ -0,0 +1,11 @@
+package models
+
+import "mime/multipart"
+
+// ProfileUploadExample represents a profile posted as a multipart form -
+// a square-ish avatar image and up to 3 attachments
+type ProfileUploadExample struct {
+	DisplayName string                  `form:"display_name" binding:"required,max=50"`
+	Avatar      *multipart.FileHeader   `form:"avatar" binding:"required,maxsize=1MB,mimetypes=image/png image/jpeg,mindimensions=64x64,maxdimensions=512x512"`
+	Attachments []*multipart.FileHeader `form:"attachments" binding:"maxfiles=3,dive,maxsize=5MB,mimetypes=application/pdf image/*"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "strings"
+
+// RuleError is what a model's Validate method returns when a rule that
+// spans more than one field fails. Tag picks the message the same way a
+// binding tag does, and every field in Fields gets that message.
+//
+// ex: &RuleError{Fields: []string{"EndTime"}, Tag: "max_session", Param: "8h"}
+type RuleError struct {
+	Fields []string
+	Tag    string
+	Param  string
+}
+
+func (e *RuleError) Error() string {
+	return strings.Join(e.Fields, ", ") + " failed on the '" + e.Tag + "' rule"
+}
+
+// RuleErrors lets Validate report more than one broken rule at a time.
+type RuleErrors []*RuleError
+
+func (e RuleErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, re := range e {
+		msgs[i] = re.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
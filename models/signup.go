@@ -0,0 +1,7 @@
+package models
+
+// SignupExample represents a new user signing up
+type SignupExample struct {
+	Username string `binding:"required,gte=5,lte=30,alphanum"`
+	Email    string `binding:"required,email,max=100"`
+}
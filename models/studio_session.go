@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// MaxSessionLength is the longest a studio can be booked for in one go.
+const MaxSessionLength = 8 * time.Hour
+
+// ErrMaxSession is the rule that a session can't be longer than
+// MaxSessionLength.
+var ErrMaxSession = &RuleError{Fields: []string{"EndTime"}, Tag: "max_session", Param: MaxSessionLength.String()}
+
+// StudioSessionExample represents a band booking some studio time
+type StudioSessionExample struct {
+	BandName    string    `binding:"required,max=30,alphanum"`
+	BandMembers int       `binding:"required,numeric,max=8"`
+	StartTime   time.Time `binding:"required"`
+	EndTime     time.Time `binding:"required,gtfield=StartTime"`
+}
+
+// Validate will check the rules that need more than one field - this runs
+// after the binding tags, so the times could still be zero.
+func (s StudioSessionExample) Validate() error {
+	if s.StartTime.IsZero() || s.EndTime.IsZero() {
+		return nil
+	}
+	if s.EndTime.Sub(s.StartTime) > MaxSessionLength {
+		return ErrMaxSession
+	}
+	return nil
+}
+
+// Rules will list the rules Validate checks.
+func (s StudioSessionExample) Rules() RuleErrors {
+	return RuleErrors{ErrMaxSession}
+}
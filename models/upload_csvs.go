@@ -0,0 +1,7 @@
+package models
+
+// UploadCsvsExample represents an uploaded csv - each row needs 3 to 50
+// cells, and each cell 5 to 1000 letters
+type UploadCsvsExample struct {
+	Content [][]string `binding:"required,max=5,dive,gte=3,max=50,dive,required,gte=5,max=1000,alpha"`
+}